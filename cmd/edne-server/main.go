@@ -0,0 +1,154 @@
+// Command edne-server serves CEP lookups over HTTP, and optionally gRPC,
+// from an eDNE base and its delta, or from a snapshot written by
+// edne.SaveSnapshot.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/NSXBet/edne/internal/server"
+	"github.com/NSXBet/edne/pkg/edne"
+	"github.com/NSXBet/edne/pkg/ednepb"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	grpcAddr := flag.String("grpc-addr", "", "address to serve gRPC on, disabled when empty")
+	base := flag.String("base", "", "directory with the eDNE base files")
+	update := flag.String("update", "", "directory with the eDNE delta files (optional)")
+	snapshotPath := flag.String("snapshot", "", "snapshot to load instead of base and update")
+	watch := flag.Duration("watch", 0, "poll the release files at this interval and reload on change, disabled when zero")
+	shutdownTimeout := flag.Duration("shutdown-timeout", 15*time.Second, "time to wait for in-flight requests on shutdown")
+	flag.Parse()
+
+	if *snapshotPath == "" && *base == "" {
+		log.Fatal("either -snapshot or -base is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := server.New()
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           srv,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	grpcServer := grpc.NewServer()
+	lookupServer := edne.NewGRPCServer()
+	ednepb.RegisterLookupServiceServer(grpcServer, lookupServer)
+
+	holder := edne.NewHolder(loader(*base, *update, *snapshotPath),
+		edne.WithPollInterval(*watch),
+		edne.WithOnReload(func(e edne.ReloadEvent) {
+			if e.Err != nil {
+				log.Printf("error reloading: %v", e.Err)
+				return
+			}
+
+			srv.SetDirectory(e.Current)
+			lookupServer.SetDirectory(e.Current)
+			log.Printf("loaded %d addresses in %s: %d added, %d removed, %d modified",
+				e.Current.Len(), e.Duration, len(e.Added), len(e.Removed), len(e.Modified))
+		}),
+	)
+
+	// Load in the background so /healthz answers while the dataset is parsed.
+	go func() {
+		// Without a dataset there is nothing to serve.
+		if err := holder.Reload(); err != nil {
+			log.Fatalf("error loading: %v", err)
+		}
+
+		if *watch > 0 {
+			paths := []string{*snapshotPath}
+			if *snapshotPath == "" {
+				paths = []string{*base}
+				if *update != "" {
+					paths = append(paths, *update)
+				}
+			}
+			_ = holder.Watch(ctx, paths...)
+		}
+	}()
+
+	// SIGHUP reloads the release without waiting for the watcher.
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	go func() {
+		for range hangups {
+			_ = holder.Reload()
+		}
+	}()
+
+	errs := make(chan error, 2)
+	go func() {
+		log.Printf("listening on %s", *addr)
+		errs <- httpServer.ListenAndServe()
+	}()
+
+	if *grpcAddr != "" {
+		listener, err := net.Listen("tcp", *grpcAddr)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		go func() {
+			log.Printf("serving gRPC on %s", *grpcAddr)
+			errs <- grpcServer.Serve(listener)
+		}()
+	}
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancel()
+
+	// Drain both servers at once so neither uses up the other's deadline.
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+
+	err := httpServer.Shutdown(shutdownCtx)
+
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		// Past the deadline, close the remaining gRPC streams.
+		grpcServer.Stop()
+		<-grpcStopped
+	}
+
+	if err != nil {
+		log.Fatalf("error shutting down: %v", err)
+	}
+}
+
+func loader(base, update, snapshotPath string) edne.Loader {
+	if snapshotPath != "" {
+		return func() (*edne.Dataset, error) {
+			return edne.LoadSnapshot(snapshotPath)
+		}
+	}
+
+	return edne.LoadDirectories(base, update)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/NSXBet/edne/pkg/edne"
+)
+
+func runDetect(args []string, stdout io.Writer) error {
+	fs := newFlagSet("detect")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return newUsageError("a directory or zip is required")
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	for _, path := range fs.Args() {
+		if err := detect(w, path); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+func detect(w io.Writer, path string) error {
+	dir, closeDir, err := edne.OpenArchive(path)
+	if err != nil {
+		return err
+	}
+	defer closeDir()
+
+	detections, err := edne.Detect(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range detections {
+		delimiter := "-"
+		if d.Delimiter != 0 {
+			delimiter = strconv.QuoteRune(d.Delimiter)
+		}
+
+		encoding := string(d.Encoding)
+		if d.BOM {
+			encoding += " (BOM)"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", filepath.Base(d.File), d.Format, delimiter, encoding)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/NSXBet/edne/pkg/edne"
+)
+
+func runDiff(args []string, stdout io.Writer) error {
+	fs := newFlagSet("diff")
+	oldUpdate := fs.String("old-update", "", "delta applied to the old base (optional)")
+	newUpdate := fs.String("new-update", "", "delta applied to the new base (optional)")
+	asJSON := fs.Bool("json", false, "print the change set as JSON")
+	deltaDir := fs.String("delta", "", "also write the changes as DELTA_ files to this directory")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 2 {
+		return newUsageError("expected the old and new base")
+	}
+
+	before, err := loadRelease(fs.Arg(0), *oldUpdate)
+	if err != nil {
+		return err
+	}
+
+	after, err := loadRelease(fs.Arg(1), *newUpdate)
+	if err != nil {
+		return err
+	}
+
+	cs := edne.Diff(before, after)
+
+	if *deltaDir != "" {
+		if err := edne.WriteChangeSetDelta(*deltaDir, cs); err != nil {
+			return err
+		}
+	}
+
+	if *asJSON {
+		return edne.WriteChangeSetJSON(stdout, cs)
+	}
+
+	for _, change := range cs.Addresses {
+		switch change.Kind {
+		case edne.ChangeAdded:
+			fmt.Fprintf(stdout, "+ %s\n", describe(*change.New))
+		case edne.ChangeRemoved:
+			fmt.Fprintf(stdout, "- %s\n", describe(*change.Old))
+		case edne.ChangeModified:
+			fmt.Fprintf(stdout, "~ %s\n", describe(*change.New))
+			for _, field := range change.Fields {
+				fmt.Fprintf(stdout, "    %s: %v -> %v\n", field.Field, field.Old, field.New)
+			}
+		}
+	}
+
+	summary := cs.Summary()
+	fmt.Fprintln(stdout)
+	for _, line := range []struct {
+		name   string
+		counts edne.ChangeCount
+	}{
+		{"addresses", summary.Addresses},
+		{"streets", summary.Streets},
+		{"neighborhoods", summary.Neighborhoods},
+		{"localities", summary.Locations},
+	} {
+		fmt.Fprintf(stdout, "%-14s %d added, %d removed, %d modified\n",
+			line.name, line.counts.Added, line.counts.Removed, line.counts.Modified)
+	}
+
+	return nil
+}
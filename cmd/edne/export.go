@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/NSXBet/edne/pkg/edne"
+)
+
+func runExport(args []string, stdout io.Writer) error {
+	var src source
+
+	fs := newFlagSet("export")
+	src.register(fs)
+	format := fs.String("format", "json", "output format: json, csv or sqlite")
+	output := fs.String("o", "", `output directory for json and csv, file for sqlite (default "." or "edne.db")`)
+	entities := fs.Bool("entities", false, "also write localities, neighborhoods and streets (json and csv)")
+	bom := fs.Bool("bom", false, "start csv files with a UTF-8 byte order mark")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 0 {
+		return newUsageError("unexpected arguments %v", fs.Args())
+	}
+
+	var opts []edne.ExportOption
+	if *entities {
+		opts = append(opts, edne.WithEntities())
+	}
+	if *bom {
+		opts = append(opts, edne.WithByteOrderMark())
+	}
+
+	var write func(dataset *edne.Dataset, output string) error
+	switch *format {
+	case "json", "jsonl":
+		write = func(dataset *edne.Dataset, output string) error {
+			return edne.ExportDataset(output, edne.ExportFormatJSONLines, dataset, opts...)
+		}
+	case "csv":
+		write = func(dataset *edne.Dataset, output string) error {
+			return edne.ExportDataset(output, edne.ExportFormatCSV, dataset, opts...)
+		}
+	case "sqlite":
+		write = func(dataset *edne.Dataset, output string) error {
+			return edne.ExportSQLite(output, dataset)
+		}
+	default:
+		return newUsageError("unknown format %q", *format)
+	}
+
+	if *output == "" {
+		*output = "."
+		if *format == "sqlite" {
+			*output = "edne.db"
+		}
+	}
+
+	dataset, err := src.load()
+	if err != nil {
+		return err
+	}
+
+	if err := write(dataset, *output); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "wrote %d addresses to %s\n", len(dataset.Addresses), *output)
+	return nil
+}
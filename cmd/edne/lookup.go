@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/NSXBet/edne/pkg/edne"
+)
+
+func runLookup(args []string, stdout io.Writer) error {
+	var src source
+
+	fs := newFlagSet("lookup")
+	src.register(fs)
+	asJSON := fs.Bool("json", false, "print the address as JSON")
+	label := fs.Bool("label", false, "print the address as on an envelope")
+	number := fs.String("number", "", "house number for -label")
+	complement := fs.String("complement", "", "complement for -label, such as \"Apto 45\"")
+	abbreviate := fs.Bool("abbreviate", false, "abbreviate names with -label")
+	width := fs.Int("width", 0, "maximum line length for -label, unlimited when zero")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		return newUsageError("expected one CEP")
+	}
+
+	zipCode, err := edne.ParseZipCode(fs.Arg(0))
+	if err != nil {
+		return newUsageError("%v", err)
+	}
+
+	d, err := src.directory()
+	if err != nil {
+		return err
+	}
+
+	address, ok := d.LookupCEP(zipCode)
+	if !ok {
+		return fmt.Errorf("CEP %s not found", edne.MaskZipCode(zipCode))
+	}
+
+	if *asJSON {
+		return edne.ExportAddresses(stdout, edne.ExportFormatJSONLines, map[int]edne.Address{zipCode: address})
+	}
+
+	if *label {
+		opts := []edne.FormatOption{
+			edne.WithNumber(*number),
+			edne.WithAddressComplement(*complement),
+			edne.WithMaxLineLength(*width),
+		}
+		if *abbreviate {
+			opts = append(opts, edne.WithAbbreviate())
+		}
+
+		lines, _ := d.PostalLines(zipCode, opts...)
+		_, err := fmt.Fprintln(stdout, strings.Join(lines, "\n"))
+		return err
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "CEP\t%s\n", edne.MaskZipCode(address.ZipCode))
+	fmt.Fprintf(w, "Street\t%s\n", strings.TrimSpace(address.StreetType+" "+address.Street))
+	if street, ok := d.Street(zipCode); ok && street.Complement != "" {
+		fmt.Fprintf(w, "Complement\t%s\n", street.Complement)
+	}
+	fmt.Fprintf(w, "Neighborhood\t%s\n", address.Neighborhood)
+	fmt.Fprintf(w, "City\t%s\n", address.City)
+	fmt.Fprintf(w, "State\t%s\n", address.State)
+	fmt.Fprintf(w, "IBGE\t%s\n", address.CityIBGECode)
+
+	return w.Flush()
+}
+
+// describe writes an address on one line, as in
+// "06415-235  Rua São Bento, Cruz de São Bento, Mosteiro Bento/SP".
+func describe(a edne.Address) string {
+	parts := []string{strings.TrimSpace(a.StreetType + " " + a.Street)}
+	if a.Neighborhood != "" {
+		parts = append(parts, a.Neighborhood)
+	}
+
+	place := a.State
+	if a.City != "" {
+		place = a.City + "/" + a.State
+	}
+	parts = append(parts, place)
+
+	return edne.MaskZipCode(a.ZipCode) + "  " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,130 @@
+// Command edne answers ad-hoc questions about an eDNE release and converts
+// it to other formats.
+//
+// Usage:
+//
+//	edne <command> [flags] [arguments]
+//
+// Every command reads the release from -base and -update, each a directory
+// or a Correios zip, or from a -snapshot written by edne.SaveSnapshot.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(args []string, stdout io.Writer) error
+}
+
+var commands = []command{
+	{"lookup", "lookup [flags] <cep>", "show the address of a CEP", runLookup},
+	{"search", "search [flags] <text>", "search addresses by street name", runSearch},
+	{"parse", "parse [flags] <address>", "split a free-text address into its parts", runParse},
+	{"export", "export [flags]", "write the release as json, csv or sqlite", runExport},
+	{"stats", "stats [flags]", "count the records of the release", runStats},
+	{"validate", "validate [flags]", "check references between records", runValidate},
+	{"diff", "diff [flags] <old> <new>", "list what changed between two releases", runDiff},
+	{"detect", "detect <dir or zip>...", "show the format and encoding of each file", runDetect},
+}
+
+// errFailed makes a command exit with status 1 after printing its output.
+var errFailed = errors.New("failed")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		usage(stderr)
+		return 2
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		err := cmd.run(args[1:], stdout)
+
+		var usageErr *usageError
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 2
+		case errors.As(err, &usageErr):
+			fmt.Fprintf(stderr, "edne %s: %v\nusage: edne %s\n", cmd.name, err, cmd.usage)
+			return 2
+		case errors.Is(err, errFailed):
+			return 1
+		default:
+			fmt.Fprintf(stderr, "edne %s: %v\n", cmd.name, err)
+			return 1
+		}
+	}
+
+	fmt.Fprintf(stderr, "edne: unknown command %q\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: edne <command> [flags] [arguments]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, `run "edne <command> -h" for the flags of a command`)
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("edne "+name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+// usageError is caused by bad arguments and exits with status 2.
+type usageError struct {
+	message string
+}
+
+func (e *usageError) Error() string {
+	return e.message
+}
+
+func newUsageError(format string, args ...any) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// parseArgs parses flags given before or after the positional arguments,
+// so "edne lookup 01001000 -base dne.zip" works as well.
+func parseArgs(fs *flag.FlagSet, args []string) error {
+	var positional []string
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return err
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+
+	return fs.Parse(append([]string{"--"}, positional...))
+}
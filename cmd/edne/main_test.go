@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func runCommand(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+
+	return code, stdout.String(), stderr.String()
+}
+
+func TestLookup(t *testing.T) {
+	code, stdout, _ := runCommand(t, "lookup", "06415-235", "-base", test.Fixture("base"), "-update", test.Fixture("update"))
+	require.Equal(t, 0, code)
+	require.Contains(t, stdout, "Rua São Bento")
+	require.Contains(t, stdout, "Mosteiro Bento")
+
+	code, stdout, _ = runCommand(t, "lookup", "-label", "-number", "10", "-abbreviate",
+		"-base", test.Fixture("base"), "-update", test.Fixture("update"), "06415235")
+	require.Equal(t, 0, code)
+	require.Equal(t, "R S Bento, 10\nCruz\nMosteiro Bento - SP\n06415-235\n", stdout)
+
+	code, _, stderr := runCommand(t, "lookup", "-base", test.Fixture("base"), "99999999")
+	require.Equal(t, 1, code)
+	require.Contains(t, stderr, "CEP 99999-999 not found")
+
+	code, _, stderr = runCommand(t, "lookup", "-base", test.Fixture("base"), "123")
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, "usage: edne lookup")
+}
+
+func TestSearch(t *testing.T) {
+	code, stdout, _ := runCommand(t, "search", "-base", test.Fixture("base"), "-state", "ES", "getulio", "vargas")
+	require.Equal(t, 0, code)
+	require.Contains(t, stdout, "29127-225  Avenida Presidente Getúlio Vargas, ES")
+
+	code, stdout, _ = runCommand(t, "search", "-base", test.Fixture("base"), "-json", "-state", "ES", "getulio", "vargas")
+	require.Equal(t, 0, code)
+	require.Regexp(t, `^\{"score":[0-9.]+,"cep":"29127225",`, stdout)
+}
+
+func TestParse(t *testing.T) {
+	code, stdout, _ := runCommand(t, "parse", "-base", test.Fixture("base"), "Est. Dias Martins, nº 1000, apto 12, Rio Branco/AC")
+	require.Equal(t, 0, code)
+	require.Regexp(t, `Complement\s+apto 12`, stdout)
+	require.Contains(t, stdout, "69919-600  Estrada Dias Martins, Rio Branco/AC")
+
+	code, stdout, _ = runCommand(t, "parse", "-base", test.Fixture("base"), "Rua Desconhecida 5, Rio Branco, AC")
+	require.Equal(t, 1, code)
+	require.Contains(t, stdout, "no matching address")
+
+	code, _, _ = runCommand(t, "parse", "-base", test.Fixture("base"))
+	require.Equal(t, 2, code)
+}
+
+func TestExport(t *testing.T) {
+	dir := t.TempDir()
+
+	code, _, _ := runCommand(t, "export", "-base", test.Fixture("base"), "-format", "csv", "-o", dir)
+	require.Equal(t, 0, code)
+	require.FileExists(t, filepath.Join(dir, "addresses.csv"))
+
+	db := filepath.Join(dir, "edne.db")
+	code, _, _ = runCommand(t, "export", "-base", test.Fixture("base"), "-format", "sqlite", "-o", db)
+	require.Equal(t, 0, code)
+	require.FileExists(t, db)
+
+	code, _, _ = runCommand(t, "export", "-base", test.Fixture("base"), "-format", "xml")
+	require.Equal(t, 2, code)
+}
+
+func TestStatsAndValidate(t *testing.T) {
+	code, stdout, _ := runCommand(t, "stats", "-base", test.Fixture("base"), "-update", test.Fixture("update"))
+	require.Equal(t, 0, code)
+	require.Regexp(t, `addresses\s+446`, stdout)
+	require.Regexp(t, `base\s+base\s+version -`, stdout)
+	require.Regexp(t, `DELTA_LOG_LOGRADOURO\.TXT\s+426 rows`, stdout)
+
+	code, stdout, _ = runCommand(t, "stats", "-base", test.Fixture("base"), "-by", "locality")
+	require.Equal(t, 0, code)
+	require.Contains(t, stdout, "AC,16,Rio Branco,M,1,6,23,9,0,7,7,0\n")
+
+	code, stdout, _ = runCommand(t, "stats", "-base", test.Fixture("base"), "-by", "state")
+	require.Equal(t, 0, code)
+	require.Contains(t, stdout, "AC,,,,20,6,52,9,19,12,12,0\n")
+
+	code, _, _ = runCommand(t, "stats", "-base", test.Fixture("base"), "-by", "street")
+	require.Equal(t, 2, code)
+
+	// Most fixture streets reference localities left out of the sample.
+	code, stdout, _ = runCommand(t, "validate", "-base", test.Fixture("base"))
+	require.Equal(t, 1, code)
+	require.Contains(t, stdout, "unknown locality")
+}
+
+func TestDiff(t *testing.T) {
+	code, stdout, _ := runCommand(t, "diff", test.Fixture("base"), test.Fixture("base"), "-new-update", test.Fixture("update"))
+	require.Equal(t, 0, code)
+	require.Contains(t, stdout, "+ 89811-169")
+	require.Regexp(t, `addresses\s+382 added, 0 removed, 0 modified`, stdout)
+
+	dir := t.TempDir()
+	code, _, _ = runCommand(t, "diff", "-delta", dir, test.Fixture("base"), test.Fixture("base"))
+	require.Equal(t, 0, code)
+	require.FileExists(t, filepath.Join(dir, "DELTA_LOG_LOGRADOURO.TXT"))
+}
+
+func TestDetect(t *testing.T) {
+	code, stdout, _ := runCommand(t, "detect", test.Fixture("base"), test.Fixture("fixed/update"))
+	require.Equal(t, 0, code)
+	require.Regexp(t, `LOG_BAIRRO\.TXT\s+delimited\s+'@'\s+windows-1252`, stdout)
+	require.Regexp(t, `DELTA_LOG_BAIRRO\.TXT\s+fixed-width\s+-\s+windows-1252`, stdout)
+
+	code, _, _ = runCommand(t, "detect")
+	require.Equal(t, 2, code)
+}
+
+func TestUnknownCommand(t *testing.T) {
+	code, _, stderr := runCommand(t, "frobnicate")
+	require.Equal(t, 2, code)
+	require.Contains(t, stderr, `unknown command "frobnicate"`)
+}
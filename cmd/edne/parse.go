@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/NSXBet/edne/pkg/edne"
+)
+
+func runParse(args []string, stdout io.Writer) error {
+	var src source
+
+	fs := newFlagSet("parse")
+	src.register(fs)
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		return newUsageError("expected an address")
+	}
+
+	d, err := src.directory()
+	if err != nil {
+		return err
+	}
+
+	parsed := d.ParseAddress(strings.Join(fs.Args(), " "))
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Street type\t%s\n", parsed.StreetType)
+	fmt.Fprintf(w, "Street\t%s\n", parsed.Street)
+	fmt.Fprintf(w, "Number\t%s\n", parsed.Number)
+	fmt.Fprintf(w, "Complement\t%s\n", parsed.Complement)
+	fmt.Fprintf(w, "Neighborhood\t%s\n", parsed.Neighborhood)
+	fmt.Fprintf(w, "City\t%s\n", parsed.City)
+	fmt.Fprintf(w, "State\t%s\n", parsed.State)
+	if parsed.ZipCode != 0 {
+		fmt.Fprintf(w, "CEP\t%s\n", edne.MaskZipCode(parsed.ZipCode))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if !parsed.Found {
+		fmt.Fprintln(stdout, "\nno matching address")
+		return errFailed
+	}
+
+	fmt.Fprintf(stdout, "\n%s\n", describe(parsed.Address))
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/NSXBet/edne/pkg/edne"
+)
+
+func runSearch(args []string, stdout io.Writer) error {
+	var src source
+
+	fs := newFlagSet("search")
+	src.register(fs)
+	state := fs.String("state", "", "only addresses in this UF")
+	city := fs.String("city", "", "only addresses in this city")
+	limit := fs.Int("limit", 20, "maximum number of results")
+	fuzzy := fs.Bool("fuzzy", false, "match a full free-text address, tolerating typos")
+	asJSON := fs.Bool("json", false, "print results as JSON lines")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	text := strings.Join(fs.Args(), " ")
+	if strings.TrimSpace(text) == "" {
+		return newUsageError("expected search text")
+	}
+	if *limit < 1 {
+		return newUsageError("-limit must be positive")
+	}
+
+	d, err := src.directory()
+	if err != nil {
+		return err
+	}
+
+	opts := []edne.SearchOption{edne.WithState(*state), edne.WithCity(*city), edne.WithLimit(*limit)}
+
+	var hits []edne.ScoredAddress
+	if *fuzzy {
+		for _, m := range d.Match(text, opts...) {
+			hits = append(hits, edne.ScoredAddress{Address: m.Address, Score: m.Confidence})
+		}
+	} else {
+		for _, r := range d.Search(text, opts...) {
+			hits = append(hits, edne.ScoredAddress{Address: r.Address, Score: r.Score})
+		}
+	}
+
+	if *asJSON {
+		return edne.ExportScoredAddresses(stdout, edne.ExportFormatJSONLines, hits)
+	}
+
+	for _, h := range hits {
+		fmt.Fprintf(stdout, "%6.2f  %s\n", h.Score, describe(h.Address))
+	}
+
+	return nil
+}
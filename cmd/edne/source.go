@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/NSXBet/edne/pkg/edne"
+)
+
+// source is where a command reads the release from.
+type source struct {
+	base     string
+	update   string
+	snapshot string
+}
+
+func (s *source) register(fs *flag.FlagSet) {
+	fs.StringVar(&s.base, "base", "", "eDNE base directory or zip")
+	fs.StringVar(&s.update, "update", "", "eDNE delta directory or zip (optional)")
+	fs.StringVar(&s.snapshot, "snapshot", "", "snapshot to read instead of -base and -update")
+}
+
+func (s *source) load() (*edne.Dataset, error) {
+	switch {
+	case s.snapshot != "":
+		return edne.LoadSnapshot(s.snapshot)
+	case s.base == "":
+		return nil, newUsageError("-base or -snapshot is required")
+	}
+
+	return loadRelease(s.base, s.update)
+}
+
+func (s *source) directory() (*edne.Directory, error) {
+	dataset, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	return edne.NewDirectory(dataset), nil
+}
+
+func loadRelease(basePath, updatePath string) (*edne.Dataset, error) {
+	return edne.LoadArchives(basePath, updatePath)()
+}
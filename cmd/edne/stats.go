@@ -0,0 +1,168 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/NSXBet/edne/pkg/edne"
+)
+
+func runStats(args []string, stdout io.Writer) error {
+	var src source
+
+	fs := newFlagSet("stats")
+	src.register(fs)
+	by := fs.String("by", "", "write counts by state, locality or type instead of the summary")
+	format := fs.String("format", "csv", "output format of -by: csv or json")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	var exportFormat edne.ExportFormat
+	switch *format {
+	case "csv":
+		exportFormat = edne.ExportFormatCSV
+	case "json", "jsonl":
+		exportFormat = edne.ExportFormatJSONLines
+	default:
+		return newUsageError("unknown format %q", *format)
+	}
+
+	switch edne.StatsGrouping(*by) {
+	case "", edne.GroupState, edne.GroupLocality, edne.GroupLocalityType:
+	default:
+		return newUsageError("unknown grouping %q", *by)
+	}
+
+	dataset, err := src.load()
+	if err != nil {
+		return err
+	}
+
+	if *by != "" {
+		return writeStats(stdout, &src, dataset, edne.StatsGrouping(*by), exportFormat)
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(w, "localities\t%d\t\n", len(dataset.Locations))
+	fmt.Fprintf(w, "neighborhoods\t%d\t\n", len(dataset.Neighborhoods))
+	fmt.Fprintf(w, "streets\t%d\t\n", len(dataset.Streets))
+	fmt.Fprintf(w, "number ranges\t%d\t\n", len(dataset.NumberRanges))
+	fmt.Fprintf(w, "addresses\t%d\t\n", len(dataset.Addresses))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout)
+
+	byState := map[string]int{}
+	for _, address := range dataset.Addresses {
+		byState[address.State]++
+	}
+
+	for _, state := range slices.Sorted(maps.Keys(byState)) {
+		fmt.Fprintf(w, "%s\t%d\t\n", state, byState[state])
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if dataset.Release == nil {
+		return nil
+	}
+	fmt.Fprintln(stdout)
+
+	return printRelease(stdout, dataset.Release)
+}
+
+// writeStats exports the counts of dataset grouped by the given grouping.
+// Large users, units and CPCs are read from the base, as the dataset does
+// not hold them; they are left out when loading a snapshot.
+func writeStats(stdout io.Writer, src *source, dataset *edne.Dataset, by edne.StatsGrouping, format edne.ExportFormat) error {
+	var opts []edne.StatsOption
+	if src.snapshot == "" {
+		var err error
+		opts, err = loadPostal(src.base)
+		if err != nil {
+			return err
+		}
+	}
+
+	rows, err := edne.NewStats(dataset, opts...).Group(by)
+	if err != nil {
+		return err
+	}
+
+	return edne.ExportStats(stdout, format, rows)
+}
+
+// loadPostal reads the large users, units and CPCs of the base in path.
+func loadPostal(path string) ([]edne.StatsOption, error) {
+	dir, closeDir, err := edne.OpenArchive(path)
+	if err != nil {
+		return nil, err
+	}
+	defer closeDir()
+
+	largeUsers, err := edne.ParseLargeUsers(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing large users: %w", err)
+	}
+
+	units, err := edne.ParseOperationalUnits(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing operational units: %w", err)
+	}
+
+	cpcs, err := edne.ParseCPCs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CPCs: %w", err)
+	}
+
+	return []edne.StatsOption{
+		edne.WithLargeUsers(largeUsers),
+		edne.WithOperationalUnits(units),
+		edne.WithCPCs(cpcs),
+	}, nil
+}
+
+// printRelease lists the sources the dataset was loaded from and their
+// files, so the base being served can be told apart from another.
+func printRelease(stdout io.Writer, release *edne.Release) error {
+	sources := []*edne.ReleaseSource{&release.Base}
+	if release.Update != nil {
+		sources = append(sources, release.Update)
+	}
+
+	w := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	for i, source := range sources {
+		label := "base"
+		if i > 0 {
+			label = "update"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\tversion %s\t%s\t%d rows\n",
+			label, source.Name, cmp.Or(source.Version, "-"), source.Date.Format(time.DateOnly), source.Rows())
+	}
+	fmt.Fprintf(w, "loaded\t%s\tin %s\n", release.LoadedAt.Format(time.RFC3339), release.Duration.Round(time.Millisecond))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, source := range sources {
+		fmt.Fprintln(stdout)
+		for _, file := range source.Files {
+			fmt.Fprintf(w, "%s\t%d rows\t%d bytes\t%s\t%s\tsha256:%s\n",
+				file.Name, file.Rows, file.Size, file.Format, file.Encoding, file.SHA256[:12])
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/NSXBet/edne/pkg/edne"
+)
+
+func runValidate(args []string, stdout io.Writer) error {
+	var src source
+
+	fs := newFlagSet("validate")
+	src.register(fs)
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	dataset, err := src.load()
+	if err != nil {
+		return err
+	}
+
+	issues := edne.Validate(dataset)
+	for _, issue := range issues {
+		fmt.Fprintln(stdout, issue)
+	}
+
+	if len(issues) > 0 {
+		fmt.Fprintf(stdout, "%d issues\n", len(issues))
+		return errFailed
+	}
+
+	fmt.Fprintln(stdout, "ok")
+	return nil
+}
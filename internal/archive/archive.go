@@ -0,0 +1,164 @@
+package archive
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var ErrNoData = errors.New("no eDNE files found")
+
+// Limits on what a zip may extract to, well above the size of a full eDNE
+// release, so that a corrupt or hostile archive cannot fill the disk.
+const (
+	maxFileSize  uint64 = 4 << 30
+	maxTotalSize uint64 = 16 << 30
+)
+
+// Open returns the directory holding the eDNE text files under path, which
+// may be that directory, a parent of it, or a zip archive as distributed by
+// Correios. Zips are extracted to a temporary directory that closeFn removes.
+func Open(path string) (dir string, closeFn func() error, err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("error opening %s: %w", path, err)
+	}
+
+	noop := func() error { return nil }
+
+	if info.IsDir() {
+		dir, err := find(path)
+		if err != nil {
+			return "", nil, err
+		}
+		return dir, noop, nil
+	}
+
+	tmp, err := os.MkdirTemp("", "edne-")
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating temporary directory: %w", err)
+	}
+	cleanup := func() error { return os.RemoveAll(tmp) }
+
+	if err := extract(path, tmp); err != nil {
+		_ = cleanup()
+		return "", nil, err
+	}
+
+	dir, err = find(tmp)
+	if err != nil {
+		_ = cleanup()
+		return "", nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	return dir, cleanup, nil
+}
+
+// IsDataFile reports whether name is one of the eDNE text files.
+func IsDataFile(name string) bool {
+	upper := strings.ToUpper(name)
+	return (strings.HasPrefix(upper, "LOG_") || strings.HasPrefix(upper, "DELTA_LOG_") ||
+		strings.HasPrefix(upper, "ECT_PAIS") || strings.HasPrefix(upper, "DELTA_ECT_PAIS")) &&
+		strings.HasSuffix(upper, ".TXT")
+}
+
+// find returns the first directory, in lexical order, holding eDNE files.
+// Correios archives put them under a "Delimitado" folder.
+func find(root string) (string, error) {
+	var dir string
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && IsDataFile(entry.Name()) {
+			dir = filepath.Dir(path)
+			return fs.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("error reading directory %s: %w", root, err)
+	}
+
+	if dir == "" {
+		return "", fmt.Errorf("%w in %s", ErrNoData, root)
+	}
+
+	return dir, nil
+}
+
+func extract(path, dst string) error {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("error opening zip %s: %w", path, err)
+	}
+	defer reader.Close()
+
+	var total uint64
+	for _, file := range reader.File {
+		if file.UncompressedSize64 > maxFileSize {
+			return fmt.Errorf("error extracting %s: %d bytes exceeds the limit of %d", file.Name, file.UncompressedSize64, maxFileSize)
+		}
+		if total += file.UncompressedSize64; total > maxTotalSize {
+			return fmt.Errorf("error opening zip %s: more than %d bytes uncompressed", path, maxTotalSize)
+		}
+	}
+
+	for _, file := range reader.File {
+		if err := extractFile(file, dst); err != nil {
+			return fmt.Errorf("error extracting %s: %w", file.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func extractFile(file *zip.File, dst string) error {
+	name := filepath.Join(dst, filepath.FromSlash(file.Name))
+	if !strings.HasPrefix(name, filepath.Clean(dst)+string(os.PathSeparator)) {
+		return fmt.Errorf("illegal path %q", file.Name)
+	}
+
+	if file.FileInfo().IsDir() {
+		return os.MkdirAll(name, 0o755)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	out, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	// archive/zip fails on entries longer than their header says, which was
+	// checked against the limits; LimitReader keeps the copy within it too.
+	if _, err := io.Copy(out, io.LimitReader(src, int64(file.UncompressedSize64))); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	// Keep the dates Correios stamped, which tell when the release was cut.
+	if !file.Modified.IsZero() {
+		return os.Chtimes(name, file.Modified, file.Modified)
+	}
+
+	return nil
+}
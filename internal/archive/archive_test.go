@@ -0,0 +1,97 @@
+package archive_test
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NSXBet/edne/internal/archive"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenDirectory(t *testing.T) {
+	dir, closeFn, err := archive.Open(test.Fixture("base"))
+	require.NoError(t, err)
+	defer closeFn()
+	require.Equal(t, test.Fixture("base"), dir)
+
+	dir, closeFn, err = archive.Open(test.FixturePath())
+	require.NoError(t, err)
+	defer closeFn()
+	require.Equal(t, test.Fixture("base"), dir)
+
+	_, _, err = archive.Open(t.TempDir())
+	require.ErrorIs(t, err, archive.ErrNoData)
+}
+
+func TestOpenZip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "eDNE_Basico.zip")
+	writeZip(t, path, test.Fixture("base"), "eDNE_Basico_24112/Delimitado/")
+
+	dir, closeFn, err := archive.Open(path)
+	require.NoError(t, err)
+	require.Equal(t, "Delimitado", filepath.Base(dir))
+	require.FileExists(t, filepath.Join(dir, "LOG_LOCALIDADE.TXT"))
+
+	// Modification times are kept, as they date the release.
+	info, err := os.Stat(filepath.Join(dir, "LOG_LOCALIDADE.TXT"))
+	require.NoError(t, err)
+	require.True(t, info.ModTime().Equal(modified), info.ModTime())
+
+	require.NoError(t, closeFn())
+	require.NoDirExists(t, dir)
+}
+
+func TestOpenZipLimits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "eDNE_Basico.zip")
+	out, err := os.Create(path)
+	require.NoError(t, err)
+
+	// An entry claiming 1 TiB is refused before anything is extracted.
+	w := zip.NewWriter(out)
+	f, err := w.CreateRaw(&zip.FileHeader{
+		Name:               "LOG_LOCALIDADE.TXT",
+		Method:             zip.Store,
+		CompressedSize64:   4,
+		UncompressedSize64: 1 << 40,
+	})
+	require.NoError(t, err)
+	_, err = f.Write([]byte("16@A"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.NoError(t, out.Close())
+
+	_, _, err = archive.Open(path)
+	require.ErrorContains(t, err, "exceeds the limit")
+}
+
+var modified = time.Date(2024, 11, 20, 10, 30, 0, 0, time.UTC)
+
+func writeZip(t *testing.T, path, src, prefix string) {
+	t.Helper()
+
+	out, err := os.Create(path)
+	require.NoError(t, err)
+	defer out.Close()
+
+	w := zip.NewWriter(out)
+	entries, err := os.ReadDir(src)
+	require.NoError(t, err)
+
+	for _, entry := range entries {
+		f, err := w.CreateHeader(&zip.FileHeader{Name: prefix + entry.Name(), Modified: modified})
+		require.NoError(t, err)
+
+		in, err := os.Open(filepath.Join(src, entry.Name()))
+		require.NoError(t, err)
+		_, err = io.Copy(f, in)
+		require.NoError(t, err)
+		in.Close()
+	}
+
+	require.NoError(t, w.Close())
+}
@@ -0,0 +1,68 @@
+package compat
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/NSXBet/edne/internal/models"
+)
+
+const brasilAPIService = "edne"
+
+// BrasilAPI is an address in the shape returned by BrasilAPI's
+// /api/cep/v1/{cep} endpoint.
+type BrasilAPI struct {
+	ZipCode      string `json:"cep"`
+	State        string `json:"state"`
+	City         string `json:"city"`
+	Neighborhood string `json:"neighborhood"`
+	Street       string `json:"street"`
+	Service      string `json:"service"`
+}
+
+// BrasilAPIError is BrasilAPI's body for a CEP no service could resolve,
+// sent with a 404 status.
+type BrasilAPIError struct {
+	Name    string                  `json:"name"`
+	Message string                  `json:"message"`
+	Type    string                  `json:"type"`
+	Errors  []BrasilAPIServiceError `json:"errors"`
+}
+
+type BrasilAPIServiceError struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+	Service string `json:"service"`
+}
+
+func NewBrasilAPI(address models.Address) BrasilAPI {
+	return BrasilAPI{
+		ZipCode:      models.FormatZipCode(address.ZipCode),
+		State:        address.State,
+		City:         address.City,
+		Neighborhood: address.Neighborhood,
+		Street:       streetName(address),
+		Service:      brasilAPIService,
+	}
+}
+
+func NewBrasilAPIError() BrasilAPIError {
+	return BrasilAPIError{
+		Name:    "CepPromiseError",
+		Message: "Todos os serviços de CEP retornaram erro.",
+		Type:    "service_error",
+		Errors: []BrasilAPIServiceError{{
+			Name:    "ServiceError",
+			Message: "CEP NAO ENCONTRADO",
+			Service: brasilAPIService,
+		}},
+	}
+}
+
+// WriteBrasilAPIJSON writes v, a BrasilAPI or a BrasilAPIError, as JSON.
+func WriteBrasilAPIJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	return encoder.Encode(v)
+}
@@ -0,0 +1,27 @@
+package compat_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/compat"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrasilAPI(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, compat.WriteBrasilAPIJSON(&buf, compat.NewBrasilAPI(address)))
+	require.JSONEq(t, `{
+		"cep": "01001000",
+		"state": "SP",
+		"city": "São Paulo",
+		"neighborhood": "Sé",
+		"street": "Praça da Sé",
+		"service": "edne"
+	}`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, compat.WriteBrasilAPIJSON(&buf, compat.NewBrasilAPIError()))
+	require.Contains(t, buf.String(), `"name":"CepPromiseError"`)
+	require.Contains(t, buf.String(), `"type":"service_error"`)
+}
@@ -0,0 +1,140 @@
+package compat
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/NSXBet/edne/internal/models"
+)
+
+// ViaCEP is an address in the shape returned by viacep.com.br. Fields the
+// eDNE does not carry (gia, ddd, siafi) are always empty, as ViaCEP itself
+// leaves them for many CEPs.
+type ViaCEP struct {
+	XMLName    xml.Name `json:"-" xml:"xmlcep"`
+	ZipCode    string   `json:"cep" xml:"cep"`
+	Street     string   `json:"logradouro" xml:"logradouro"`
+	Complement string   `json:"complemento" xml:"complemento"`
+	Unit       string   `json:"unidade" xml:"unidade"`
+	District   string   `json:"bairro" xml:"bairro"`
+	City       string   `json:"localidade" xml:"localidade"`
+	State      string   `json:"uf" xml:"uf"`
+	StateName  string   `json:"estado" xml:"estado"`
+	Region     string   `json:"regiao" xml:"regiao"`
+	IBGECode   string   `json:"ibge" xml:"ibge"`
+	GIA        string   `json:"gia" xml:"gia"`
+	AreaCode   string   `json:"ddd" xml:"ddd"`
+	SIAFICode  string   `json:"siafi" xml:"siafi"`
+}
+
+// ViaCEPError is what ViaCEP answers for a well-formed CEP it does not know.
+type ViaCEPError struct {
+	XMLName xml.Name `json:"-" xml:"xmlcep"`
+	Error   bool     `json:"erro" xml:"erro"`
+}
+
+type ViaCEPOption func(opts *ViaCEPOptions)
+
+type ViaCEPOptions struct {
+	Complement string
+}
+
+// WithComplement fills "complemento", usually the street complement such
+// as "lado ímpar", which the joined Address does not carry.
+func WithComplement(complement string) ViaCEPOption {
+	return func(opts *ViaCEPOptions) {
+		opts.Complement = complement
+	}
+}
+
+func NewViaCEP(address models.Address, opts ...ViaCEPOption) ViaCEP {
+	options := &ViaCEPOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	state := states[strings.ToUpper(address.State)]
+
+	return ViaCEP{
+		ZipCode:    models.MaskZipCode(address.ZipCode),
+		Street:     streetName(address),
+		Complement: options.Complement,
+		District:   address.Neighborhood,
+		City:       address.City,
+		State:      address.State,
+		StateName:  state.name,
+		Region:     state.region,
+		IBGECode:   address.CityIBGECode,
+	}
+}
+
+func NewViaCEPError() ViaCEPError {
+	return ViaCEPError{Error: true}
+}
+
+// WriteViaCEPJSON writes v, a ViaCEP or a ViaCEPError, as ViaCEP's JSON.
+func WriteViaCEPJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+
+	return encoder.Encode(v)
+}
+
+// WriteViaCEPXML writes v, a ViaCEP or a ViaCEPError, as ViaCEP's XML.
+func WriteViaCEPXML(w io.Writer, v any) error {
+	if _, err := io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// streetName joins the street type and name, as public CEP APIs show them.
+func streetName(address models.Address) string {
+	return strings.TrimSpace(address.StreetType + " " + address.Street)
+}
+
+type state struct {
+	name   string
+	region string
+}
+
+var states = map[string]state{
+	"AC": {"Acre", "Norte"},
+	"AL": {"Alagoas", "Nordeste"},
+	"AP": {"Amapá", "Norte"},
+	"AM": {"Amazonas", "Norte"},
+	"BA": {"Bahia", "Nordeste"},
+	"CE": {"Ceará", "Nordeste"},
+	"DF": {"Distrito Federal", "Centro-Oeste"},
+	"ES": {"Espírito Santo", "Sudeste"},
+	"GO": {"Goiás", "Centro-Oeste"},
+	"MA": {"Maranhão", "Nordeste"},
+	"MT": {"Mato Grosso", "Centro-Oeste"},
+	"MS": {"Mato Grosso do Sul", "Centro-Oeste"},
+	"MG": {"Minas Gerais", "Sudeste"},
+	"PA": {"Pará", "Norte"},
+	"PB": {"Paraíba", "Nordeste"},
+	"PR": {"Paraná", "Sul"},
+	"PE": {"Pernambuco", "Nordeste"},
+	"PI": {"Piauí", "Nordeste"},
+	"RJ": {"Rio de Janeiro", "Sudeste"},
+	"RN": {"Rio Grande do Norte", "Nordeste"},
+	"RS": {"Rio Grande do Sul", "Sul"},
+	"RO": {"Rondônia", "Norte"},
+	"RR": {"Roraima", "Norte"},
+	"SC": {"Santa Catarina", "Sul"},
+	"SP": {"São Paulo", "Sudeste"},
+	"SE": {"Sergipe", "Nordeste"},
+	"TO": {"Tocantins", "Norte"},
+}
@@ -0,0 +1,60 @@
+package compat_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/compat"
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+var address = models.Address{
+	StreetType:   "Praça",
+	Street:       "da Sé",
+	Neighborhood: "Sé",
+	City:         "São Paulo",
+	CityIBGECode: "3550308",
+	State:        "SP",
+	ZipCode:      1001000,
+}
+
+func TestViaCEPJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, compat.WriteViaCEPJSON(&buf, compat.NewViaCEP(address, compat.WithComplement("lado ímpar"))))
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Equal(t, map[string]any{
+		"cep":         "01001-000",
+		"logradouro":  "Praça da Sé",
+		"complemento": "lado ímpar",
+		"unidade":     "",
+		"bairro":      "Sé",
+		"localidade":  "São Paulo",
+		"uf":          "SP",
+		"estado":      "São Paulo",
+		"regiao":      "Sudeste",
+		"ibge":        "3550308",
+		"gia":         "",
+		"ddd":         "",
+		"siafi":       "",
+	}, got)
+
+	buf.Reset()
+	require.NoError(t, compat.WriteViaCEPJSON(&buf, compat.NewViaCEPError()))
+	require.JSONEq(t, `{"erro": true}`, buf.String())
+}
+
+func TestViaCEPXML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, compat.WriteViaCEPXML(&buf, compat.NewViaCEP(address)))
+	require.Contains(t, buf.String(), `<?xml version="1.0" encoding="UTF-8"?>`)
+	require.Contains(t, buf.String(), "<xmlcep>\n  <cep>01001-000</cep>\n  <logradouro>Praça da Sé</logradouro>")
+	require.Contains(t, buf.String(), "<ibge>3550308</ibge>")
+
+	buf.Reset()
+	require.NoError(t, compat.WriteViaCEPXML(&buf, compat.NewViaCEPError()))
+	require.Contains(t, buf.String(), "<xmlcep>\n  <erro>true</erro>\n</xmlcep>")
+}
@@ -0,0 +1,250 @@
+package diff
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/internal/writer"
+)
+
+type Kind string
+
+const (
+	KindAdded    Kind = "added"
+	KindRemoved  Kind = "removed"
+	KindModified Kind = "modified"
+)
+
+// FieldChange is a field whose value differs between releases. Neighborhood
+// references are compared, and reported, by ID.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// Change is a record added, removed or modified between releases. Old is
+// nil for added records and New for removed ones.
+type Change[T any] struct {
+	Kind   Kind          `json:"kind"`
+	ID     int           `json:"id"`
+	Old    *T            `json:"old,omitempty"`
+	New    *T            `json:"new,omitempty"`
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// ChangeSet lists what changed between two releases, each slice ordered by
+// ID. Addresses and streets are identified by CEP and LOG_NU respectively,
+// so a street whose CEP changed is a single modification.
+type ChangeSet struct {
+	Addresses     []Change[models.Address]      `json:"addresses"`
+	Streets       []Change[models.Street]       `json:"streets"`
+	Neighborhoods []Change[models.Neighborhood] `json:"neighborhoods"`
+	Locations     []Change[models.Location]     `json:"localities"`
+	// NumberRanges are identified by the ID of their street.
+	NumberRanges []Change[models.NumberRange] `json:"number_ranges"`
+}
+
+type Counts struct {
+	Added    int `json:"added"`
+	Removed  int `json:"removed"`
+	Modified int `json:"modified"`
+}
+
+type Summary struct {
+	Addresses     Counts `json:"addresses"`
+	Streets       Counts `json:"streets"`
+	Neighborhoods Counts `json:"neighborhoods"`
+	Locations     Counts `json:"localities"`
+	NumberRanges  Counts `json:"number_ranges"`
+}
+
+// Datasets compares two parsed releases.
+func Datasets(before, after *models.Dataset) *ChangeSet {
+	return &ChangeSet{
+		Addresses:     changes(before.Addresses, after.Addresses),
+		Streets:       changes(streetsByID(before.Streets), streetsByID(after.Streets)),
+		Neighborhoods: changes(before.Neighborhoods, after.Neighborhoods),
+		Locations:     changes(before.Locations, after.Locations),
+		NumberRanges:  changes(before.NumberRanges, after.NumberRanges),
+	}
+}
+
+// Releases parses and compares two base directories.
+func Releases(before, after string) (*ChangeSet, error) {
+	masterParser := parser.NewMasterParser()
+
+	old, err := masterParser.ParseDataset(before, "")
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", before, err)
+	}
+
+	current, err := masterParser.ParseDataset(after, "")
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", after, err)
+	}
+
+	return Datasets(old, current), nil
+}
+
+func (cs *ChangeSet) Empty() bool {
+	return len(cs.Addresses) == 0 && len(cs.Streets) == 0 &&
+		len(cs.Neighborhoods) == 0 && len(cs.Locations) == 0 && len(cs.NumberRanges) == 0
+}
+
+func (cs *ChangeSet) Summary() Summary {
+	return Summary{
+		Addresses:     count(cs.Addresses),
+		Streets:       count(cs.Streets),
+		Neighborhoods: count(cs.Neighborhoods),
+		Locations:     count(cs.Locations),
+		NumberRanges:  count(cs.NumberRanges),
+	}
+}
+
+// Delta turns the entity changes into the INS/UPD/DEL operations of a
+// Correios delta. Addresses are left out, since they are joined from the
+// other entities.
+func (cs *ChangeSet) Delta() *models.Delta {
+	delta := &models.Delta{}
+
+	for _, change := range cs.Locations {
+		location, op := record(change)
+		c := models.LocationChange{Operation: op, Location: location}
+		if op == models.OperationUpdate {
+			c.PreviousZipCode = change.Old.ZipCode
+		}
+		delta.Locations = append(delta.Locations, c)
+	}
+
+	for _, change := range cs.Neighborhoods {
+		neighborhood, op := record(change)
+		delta.Neighborhoods = append(delta.Neighborhoods, models.NeighborhoodChange{Operation: op, Neighborhood: neighborhood})
+	}
+
+	for _, change := range cs.Streets {
+		street, op := record(change)
+		c := models.StreetChange{Operation: op, Street: street}
+		if op == models.OperationUpdate {
+			c.PreviousZipCode = change.Old.ZipCode
+		}
+		delta.Streets = append(delta.Streets, c)
+	}
+
+	for _, change := range cs.NumberRanges {
+		r, op := record(change)
+		delta.NumberRanges = append(delta.NumberRanges, models.NumberRangeChange{Operation: op, NumberRange: r})
+	}
+
+	return delta
+}
+
+// WriteJSON writes the change set as indented JSON.
+func WriteJSON(w io.Writer, cs *ChangeSet) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(cs)
+}
+
+// record returns the state a delta operation carries: the new record, or
+// the removed one for deletions.
+func record[T any](change Change[T]) (T, models.Operation) {
+	switch change.Kind {
+	case KindAdded:
+		return *change.New, models.OperationInsert
+	case KindRemoved:
+		return *change.Old, models.OperationDelete
+	default:
+		return *change.New, models.OperationUpdate
+	}
+}
+
+func changes[T any](before, after map[int]T) []Change[T] {
+	var result []Change[T]
+
+	for id, current := range after {
+		old, ok := before[id]
+		if !ok {
+			result = append(result, Change[T]{Kind: KindAdded, ID: id, New: &current})
+			continue
+		}
+
+		if fields := fieldChanges(old, current); len(fields) > 0 {
+			result = append(result, Change[T]{Kind: KindModified, ID: id, Old: &old, New: &current, Fields: fields})
+		}
+	}
+
+	for id, old := range before {
+		if _, ok := after[id]; !ok {
+			result = append(result, Change[T]{Kind: KindRemoved, ID: id, Old: &old})
+		}
+	}
+
+	slices.SortFunc(result, func(a, b Change[T]) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return result
+}
+
+var neighborhoodPointer = reflect.TypeFor[*models.Neighborhood]()
+
+// fieldChanges compares two structs of the same type field by field.
+func fieldChanges[T any](old, current T) []FieldChange {
+	var fields []FieldChange
+
+	a, b := reflect.ValueOf(old), reflect.ValueOf(current)
+	for i := range a.NumField() {
+		x, y := fieldValue(a.Field(i)), fieldValue(b.Field(i))
+		if x != y {
+			fields = append(fields, FieldChange{Field: a.Type().Field(i).Name, Old: x, New: y})
+		}
+	}
+
+	return fields
+}
+
+func fieldValue(v reflect.Value) any {
+	if v.Type() == neighborhoodPointer {
+		if v.IsNil() {
+			return 0
+		}
+		return v.Interface().(*models.Neighborhood).ID
+	}
+	return v.Interface()
+}
+
+func streetsByID(streets map[int]models.Street) map[int]models.Street {
+	m := make(map[int]models.Street, len(streets))
+	for _, street := range streets {
+		m[street.ID] = street
+	}
+	return m
+}
+
+func count[T any](changes []Change[T]) Counts {
+	var c Counts
+	for _, change := range changes {
+		switch change.Kind {
+		case KindAdded:
+			c.Added++
+		case KindRemoved:
+			c.Removed++
+		case KindModified:
+			c.Modified++
+		}
+	}
+	return c
+}
+
+// WriteDelta writes the change set as Correios DELTA_ files to dir.
+func WriteDelta(dir string, cs *ChangeSet) error {
+	return writer.Delta(dir, cs.Delta())
+}
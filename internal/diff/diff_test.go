@@ -0,0 +1,146 @@
+package diff_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/diff"
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+// edited returns the base fixtures with a street renamed and moved to a new
+// CEP, a locality removed and a neighborhood added.
+func edited(t *testing.T) (before, after *models.Dataset) {
+	t.Helper()
+
+	masterParser := parser.NewMasterParser()
+
+	before, err := masterParser.ParseDataset(test.Fixture("base"), "")
+	require.NoError(t, err)
+
+	after, err = masterParser.ParseDataset(test.Fixture("base"), "")
+	require.NoError(t, err)
+
+	// 1005314@DF@1778@1128@@SCEN Trecho 2 Conjunto 4@@70800122@Trecho@N@SCEN Tr 2 Cj 4
+	street := after.Streets[70800122]
+	delete(after.Streets, 70800122)
+	street.Name = "SCEN Trecho 2 Conjunto 5"
+	street.ZipCode = 70800999
+	after.Streets[70800999] = street
+
+	address := after.Addresses[70800122]
+	delete(after.Addresses, 70800122)
+	address.Street = street.Name
+	address.ZipCode = street.ZipCode
+	after.Addresses[70800999] = address
+
+	delete(after.Locations, 2)
+
+	after.Neighborhoods[99999] = models.Neighborhood{ID: 99999, State: "AC", LocationID: 16, Name: "Novo Bairro"}
+
+	return before, after
+}
+
+func TestDatasets(t *testing.T) {
+	before, after := edited(t)
+
+	cs := diff.Datasets(before, after)
+	require.False(t, cs.Empty())
+	require.Equal(t, diff.Summary{
+		Addresses:     diff.Counts{Added: 1, Removed: 1},
+		Streets:       diff.Counts{Modified: 1},
+		Neighborhoods: diff.Counts{Added: 1},
+		Locations:     diff.Counts{Removed: 1},
+	}, cs.Summary())
+
+	street := cs.Streets[0]
+	require.Equal(t, diff.KindModified, street.Kind)
+	require.Equal(t, 1005314, street.ID)
+	require.Equal(t, []diff.FieldChange{
+		{Field: "ZipCode", Old: 70800122, New: 70800999},
+		{Field: "Name", Old: "SCEN Trecho 2 Conjunto 4", New: "SCEN Trecho 2 Conjunto 5"},
+	}, street.Fields)
+
+	require.Equal(t, diff.KindRemoved, cs.Locations[0].Kind)
+	require.Equal(t, "Assis Brasil", cs.Locations[0].Old.Name)
+	require.Nil(t, cs.Locations[0].New)
+
+	require.Equal(t, []int{70800122, 70800999}, []int{cs.Addresses[0].ID, cs.Addresses[1].ID})
+	require.Equal(t, diff.KindRemoved, cs.Addresses[0].Kind)
+	require.Equal(t, diff.KindAdded, cs.Addresses[1].Kind)
+
+	require.True(t, diff.Datasets(before, before).Empty())
+}
+
+func TestChangeSetDelta(t *testing.T) {
+	cs := diff.Datasets(edited(t))
+
+	delta := cs.Delta()
+	require.Len(t, delta.Streets, 1)
+	require.Equal(t, models.OperationUpdate, delta.Streets[0].Operation)
+	require.Equal(t, 70800999, delta.Streets[0].Street.ZipCode)
+	require.Equal(t, 70800122, delta.Streets[0].PreviousZipCode)
+
+	require.Len(t, delta.Locations, 1)
+	require.Equal(t, models.OperationDelete, delta.Locations[0].Operation)
+	require.Equal(t, 2, delta.Locations[0].Location.ID)
+
+	require.Len(t, delta.Neighborhoods, 1)
+	require.Equal(t, models.OperationInsert, delta.Neighborhoods[0].Operation)
+
+	dir := t.TempDir()
+	require.NoError(t, diff.WriteDelta(dir, cs))
+
+	parsed, err := parser.NewDeltaParser().Parse(dir)
+	require.NoError(t, err)
+	require.Equal(t, delta, parsed)
+}
+
+func TestWriteJSON(t *testing.T) {
+	cs := diff.Datasets(edited(t))
+
+	var buf bytes.Buffer
+	require.NoError(t, diff.WriteJSON(&buf, cs))
+
+	var decoded struct {
+		Streets []struct {
+			Kind   string `json:"kind"`
+			ID     int    `json:"id"`
+			Fields []struct {
+				Field string `json:"field"`
+				Old   any    `json:"old"`
+				New   any    `json:"new"`
+			} `json:"fields"`
+		} `json:"streets"`
+		Localities []struct {
+			Kind string          `json:"kind"`
+			New  json.RawMessage `json:"new"`
+		} `json:"localities"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+
+	require.Equal(t, "modified", decoded.Streets[0].Kind)
+	require.Equal(t, "Name", decoded.Streets[0].Fields[1].Field)
+	require.Equal(t, "removed", decoded.Localities[0].Kind)
+	require.Nil(t, decoded.Localities[0].New)
+}
+
+func TestWriteDeltaRoundTrip(t *testing.T) {
+	before, after := edited(t)
+
+	dir := t.TempDir()
+	require.NoError(t, diff.WriteDelta(dir, diff.Datasets(before, after)))
+
+	applied, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), dir)
+	require.NoError(t, err)
+
+	require.Equal(t, after.Locations, applied.Locations)
+	require.Equal(t, after.Neighborhoods, applied.Neighborhoods)
+	require.Equal(t, after.Streets, applied.Streets)
+	require.Equal(t, after.NumberRanges, applied.NumberRanges)
+	require.Equal(t, parser.JoinAddresses(after.Streets, after.Neighborhoods, after.Locations), applied.Addresses)
+}
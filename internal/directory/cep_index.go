@@ -0,0 +1,163 @@
+package directory
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"sort"
+	"strings"
+
+	"github.com/NSXBet/edne/internal/models"
+)
+
+const (
+	defaultPageSize = 100
+	zipCodeDigits   = 8
+)
+
+var ErrInvalidPrefix = errors.New("invalid CEP prefix")
+
+type PageOption func(opts *PageOptions)
+
+type PageOptions struct {
+	Size  int
+	After int
+}
+
+// WithPageSize sets how many addresses a page holds.
+func WithPageSize(size int) PageOption {
+	return func(opts *PageOptions) {
+		opts.Size = size
+	}
+}
+
+// WithCursor resumes after the CEP returned as Page.Next by the previous
+// page.
+func WithCursor(after int) PageOption {
+	return func(opts *PageOptions) {
+		opts.After = after
+	}
+}
+
+type Page struct {
+	Addresses []models.Address
+	// Next is the cursor for the following page, or zero on the last page.
+	Next int
+	// Total is the number of addresses in the whole range.
+	Total int
+}
+
+// Range returns the addresses with CEPs between from and to, inclusive, in
+// CEP order.
+func (d *Directory) Range(from, to int, opts ...PageOption) Page {
+	options := &PageOptions{Size: defaultPageSize}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	lo, hi := d.bounds(from, to)
+	page := Page{Total: hi - lo}
+
+	start := lo
+	if options.After > 0 {
+		after, _ := d.bounds(options.After+1, to)
+		start = max(start, after)
+	}
+
+	end := hi
+	if options.Size > 0 && start+options.Size < hi {
+		end = start + options.Size
+		page.Next = d.zipCodes[end-1]
+	}
+
+	page.Addresses = make([]models.Address, 0, max(end-start, 0))
+	for _, zipCode := range d.zipCodes[start:max(end, start)] {
+		page.Addresses = append(page.Addresses, d.dataset.Addresses[zipCode])
+	}
+
+	return page
+}
+
+// Prefix returns the addresses whose CEP starts with prefix, such as "2910"
+// or "29100-".
+func (d *Directory) Prefix(prefix string, opts ...PageOption) (Page, error) {
+	from, to, err := PrefixRange(prefix)
+	if err != nil {
+		return Page{}, err
+	}
+
+	return d.Range(from, to, opts...), nil
+}
+
+// Between iterates over the addresses with CEPs between from and to,
+// inclusive, without paging.
+func (d *Directory) Between(from, to int) iter.Seq2[int, models.Address] {
+	return func(yield func(int, models.Address) bool) {
+		lo, hi := d.bounds(from, to)
+		for _, zipCode := range d.zipCodes[lo:hi] {
+			if !yield(zipCode, d.dataset.Addresses[zipCode]) {
+				return
+			}
+		}
+	}
+}
+
+// Nearest returns up to limit addresses whose CEPs are numerically closest
+// to zipCode, closest first, preferring the lower CEP on ties.
+func (d *Directory) Nearest(zipCode int, limit int) []models.Address {
+	i := sort.SearchInts(d.zipCodes, zipCode)
+	lo, hi := i-1, i
+
+	var addresses []models.Address
+	for len(addresses) < limit && (lo >= 0 || hi < len(d.zipCodes)) {
+		var next int
+		switch {
+		case lo < 0:
+			next, hi = d.zipCodes[hi], hi+1
+		case hi >= len(d.zipCodes):
+			next, lo = d.zipCodes[lo], lo-1
+		case zipCode-d.zipCodes[lo] <= d.zipCodes[hi]-zipCode:
+			next, lo = d.zipCodes[lo], lo-1
+		default:
+			next, hi = d.zipCodes[hi], hi+1
+		}
+		addresses = append(addresses, d.dataset.Addresses[next])
+	}
+
+	return addresses
+}
+
+// PrefixRange returns the first and last CEPs starting with prefix.
+func PrefixRange(prefix string) (int, int, error) {
+	digits := strings.TrimRight(strings.TrimSpace(prefix), "-")
+	digits = strings.Replace(digits, "-", "", 1)
+
+	if digits == "" || len(digits) > zipCodeDigits {
+		return 0, 0, fmt.Errorf("%w: %q", ErrInvalidPrefix, prefix)
+	}
+
+	from := 0
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, 0, fmt.Errorf("%w: %q", ErrInvalidPrefix, prefix)
+		}
+		from = from*10 + int(r-'0')
+	}
+
+	scale := 1
+	for i := len(digits); i < zipCodeDigits; i++ {
+		scale *= 10
+	}
+
+	return from * scale, (from+1)*scale - 1, nil
+}
+
+// bounds returns the index range of zipCodes between from and to.
+func (d *Directory) bounds(from, to int) (int, int) {
+	lo := sort.SearchInts(d.zipCodes, from)
+	hi := sort.SearchInts(d.zipCodes, to+1)
+	if hi < lo {
+		hi = lo
+	}
+	return lo, hi
+}
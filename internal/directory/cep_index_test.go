@@ -0,0 +1,92 @@
+package directory_test
+
+import (
+	"testing"
+
+	"github.com/NSXBet/edne/internal/directory"
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func zipCodes(addresses []models.Address) []int {
+	codes := make([]int, len(addresses))
+	for i, a := range addresses {
+		codes[i] = a.ZipCode
+	}
+	return codes
+}
+
+func TestDirectoryPrefix(t *testing.T) {
+	d := newDirectory(t)
+
+	page, err := d.Prefix("699")
+	require.NoError(t, err)
+	require.Equal(t, 11, page.Total)
+	require.Zero(t, page.Next)
+	require.Equal(t, 69908648, page.Addresses[0].ZipCode)
+	require.Equal(t, 69919600, page.Addresses[10].ZipCode)
+
+	page, err = d.Prefix("69917-")
+	require.NoError(t, err)
+	require.Equal(t, []int{69917520, 69917555, 69917560, 69917766}, zipCodes(page.Addresses))
+
+	page, err = d.Prefix("69917-555")
+	require.NoError(t, err)
+	require.Equal(t, []int{69917555}, zipCodes(page.Addresses))
+
+	for _, prefix := range []string{"", "abc", "123456789"} {
+		_, err = d.Prefix(prefix)
+		require.ErrorIs(t, err, directory.ErrInvalidPrefix, prefix)
+	}
+}
+
+func TestDirectoryRangePagination(t *testing.T) {
+	d := newDirectory(t)
+
+	var all []int
+	var cursor, pages int
+	for {
+		page := d.Range(69900000, 69999999, directory.WithPageSize(5), directory.WithCursor(cursor))
+		require.Equal(t, 11, page.Total)
+		all = append(all, zipCodes(page.Addresses)...)
+		pages++
+		if page.Next == 0 {
+			break
+		}
+		cursor = page.Next
+	}
+
+	require.Equal(t, 3, pages)
+	require.Len(t, all, 11)
+	for i := 1; i < len(all); i++ {
+		require.Less(t, all[i-1], all[i])
+	}
+
+	var between []int
+	for zipCode := range d.Between(69900000, 69999999) {
+		between = append(between, zipCode)
+	}
+	require.Equal(t, all, between)
+
+	require.Empty(t, d.Range(1, 2).Addresses)
+	require.Empty(t, d.Range(69999999, 69900000).Addresses)
+}
+
+func TestDirectoryNearest(t *testing.T) {
+	d := newDirectory(t)
+
+	nearest := d.Nearest(69917557, 3)
+	require.Equal(t, []int{69917555, 69917560, 69917520}, zipCodes(nearest))
+
+	nearest = d.Nearest(69917555, 1)
+	require.Equal(t, []int{69917555}, zipCodes(nearest))
+
+	require.Len(t, d.Nearest(0, 500), 446)
+}
+
+func TestPrefixRange(t *testing.T) {
+	from, to, err := directory.PrefixRange("2910")
+	require.NoError(t, err)
+	require.Equal(t, 29100000, from)
+	require.Equal(t, 29109999, to)
+}
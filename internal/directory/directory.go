@@ -0,0 +1,207 @@
+package directory
+
+import (
+	"iter"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/search"
+)
+
+// Directory answers lookups over a parsed dataset. Its indexes are built once
+// by New and the dataset must not be modified afterwards.
+type Directory struct {
+	dataset *models.Dataset
+
+	zipCodes                []int
+	localitiesByState       map[string][]models.Location
+	localitiesByIBGE        map[string]models.Location
+	localitiesByZipCode     map[int]models.Location
+	neighborhoodsByLocality map[int][]models.Neighborhood
+	streetsByNeighborhood   map[int][]models.Street
+	streetsByLocality       map[int][]models.Street
+
+	searchOnce  sync.Once
+	searchIndex *search.Index
+
+	reverseOnce  sync.Once
+	reverseIndex map[string][]section
+
+	parseOnce           sync.Once
+	streetTypes         map[string]string
+	streetTypeWords     int
+	localitiesByName    map[string][]models.Location
+	neighborhoodsByName map[string][]models.Neighborhood
+}
+
+func New(dataset *models.Dataset) *Directory {
+	d := &Directory{
+		dataset:                 dataset,
+		zipCodes:                make([]int, 0, len(dataset.Addresses)),
+		localitiesByState:       map[string][]models.Location{},
+		localitiesByIBGE:        map[string]models.Location{},
+		localitiesByZipCode:     map[int]models.Location{},
+		neighborhoodsByLocality: map[int][]models.Neighborhood{},
+		streetsByNeighborhood:   map[int][]models.Street{},
+		streetsByLocality:       map[int][]models.Street{},
+	}
+
+	for zipCode := range dataset.Addresses {
+		d.zipCodes = append(d.zipCodes, zipCode)
+	}
+	sort.Ints(d.zipCodes)
+
+	for _, location := range dataset.Locations {
+		d.localitiesByState[location.State] = append(d.localitiesByState[location.State], location)
+
+		// Districts and villages carry the code of their municipality, if
+		// any, so only municipalities are indexed by it. Should two share a
+		// code the lowest ID wins, keeping lookups deterministic.
+		if location.IBGECode != "" && location.Type == models.LocationTypeCity {
+			if current, ok := d.localitiesByIBGE[location.IBGECode]; !ok || location.ID < current.ID {
+				d.localitiesByIBGE[location.IBGECode] = location
+			}
+		}
+
+		// Only localities without streets of their own have a CEP.
+		if location.ZipCode != 0 {
+			d.localitiesByZipCode[location.ZipCode] = location
+		}
+	}
+	for _, locations := range d.localitiesByState {
+		sort.Slice(locations, func(i, j int) bool {
+			return locations[i].Name < locations[j].Name ||
+				locations[i].Name == locations[j].Name && locations[i].ID < locations[j].ID
+		})
+	}
+
+	for _, neighborhood := range dataset.Neighborhoods {
+		d.neighborhoodsByLocality[neighborhood.LocationID] = append(
+			d.neighborhoodsByLocality[neighborhood.LocationID], neighborhood)
+	}
+	for _, neighborhoods := range d.neighborhoodsByLocality {
+		sort.Slice(neighborhoods, func(i, j int) bool {
+			return neighborhoods[i].Name < neighborhoods[j].Name ||
+				neighborhoods[i].Name == neighborhoods[j].Name && neighborhoods[i].ID < neighborhoods[j].ID
+		})
+	}
+
+	for _, street := range dataset.Streets {
+		if street.StartingNeighborhood != nil {
+			id := street.StartingNeighborhood.ID
+			d.streetsByNeighborhood[id] = append(d.streetsByNeighborhood[id], street)
+		}
+		d.streetsByLocality[street.LocationID] = append(d.streetsByLocality[street.LocationID], street)
+	}
+	for _, streets := range d.streetsByNeighborhood {
+		sortStreets(streets)
+	}
+	for _, streets := range d.streetsByLocality {
+		sortStreets(streets)
+	}
+
+	return d
+}
+
+// Dataset returns the dataset the directory was built from.
+func (d *Directory) Dataset() *models.Dataset {
+	return d.dataset
+}
+
+// Len returns the number of addresses.
+func (d *Directory) Len() int {
+	return len(d.zipCodes)
+}
+
+// All iterates over every address in CEP order.
+func (d *Directory) All() iter.Seq2[int, models.Address] {
+	return func(yield func(int, models.Address) bool) {
+		for _, zipCode := range d.zipCodes {
+			if !yield(zipCode, d.dataset.Addresses[zipCode]) {
+				return
+			}
+		}
+	}
+}
+
+// Addresses returns the addresses keyed by CEP, as Parser.Parse used to.
+func (d *Directory) Addresses() map[int]models.Address {
+	return d.dataset.Addresses
+}
+
+func (d *Directory) LookupCEP(zipCode int) (models.Address, bool) {
+	address, ok := d.dataset.Addresses[zipCode]
+	return address, ok
+}
+
+func (d *Directory) Street(zipCode int) (models.Street, bool) {
+	street, ok := d.dataset.Streets[zipCode]
+	return street, ok
+}
+
+func (d *Directory) Locality(id int) (models.Location, bool) {
+	location, ok := d.dataset.Locations[id]
+	return location, ok
+}
+
+// LocalityByIBGE returns the municipality with the given IBGE code.
+func (d *Directory) LocalityByIBGE(code string) (models.Location, bool) {
+	location, ok := d.localitiesByIBGE[strings.TrimSpace(code)]
+	return location, ok
+}
+
+// LocalitiesInState returns the localities of a UF ordered by name.
+func (d *Directory) LocalitiesInState(state string) []models.Location {
+	return slices.Clone(d.localitiesByState[strings.ToUpper(strings.TrimSpace(state))])
+}
+
+func (d *Directory) Neighborhood(id int) (models.Neighborhood, bool) {
+	neighborhood, ok := d.dataset.Neighborhoods[id]
+	return neighborhood, ok
+}
+
+// NeighborhoodsInLocality returns the neighborhoods of a locality ordered by
+// name.
+func (d *Directory) NeighborhoodsInLocality(locationID int) []models.Neighborhood {
+	return slices.Clone(d.neighborhoodsByLocality[locationID])
+}
+
+// StreetsInNeighborhood returns the streets starting in a neighborhood
+// ordered by CEP.
+func (d *Directory) StreetsInNeighborhood(neighborhoodID int) []models.Street {
+	return slices.Clone(d.streetsByNeighborhood[neighborhoodID])
+}
+
+// StreetsInLocality returns the streets of a locality ordered by CEP.
+func (d *Directory) StreetsInLocality(locationID int) []models.Street {
+	return slices.Clone(d.streetsByLocality[locationID])
+}
+
+// Search finds addresses by street name ignoring accents and case. The
+// search index is built on first use.
+func (d *Directory) Search(text string, opts ...search.SearchOption) []search.Result {
+	return d.index().Search(text, opts...)
+}
+
+// Match finds the addresses that best fit a free-form address, tolerating
+// abbreviations and typos.
+func (d *Directory) Match(text string, opts ...search.SearchOption) []search.Match {
+	return d.index().Match(text, opts...)
+}
+
+func (d *Directory) index() *search.Index {
+	d.searchOnce.Do(func() {
+		d.searchIndex = search.NewIndex(d.All())
+	})
+
+	return d.searchIndex
+}
+
+func sortStreets(streets []models.Street) {
+	sort.Slice(streets, func(i, j int) bool {
+		return streets[i].ZipCode < streets[j].ZipCode
+	})
+}
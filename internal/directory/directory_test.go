@@ -0,0 +1,168 @@
+package directory_test
+
+import (
+	"testing"
+
+	"github.com/NSXBet/edne/internal/directory"
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/internal/postal"
+	"github.com/NSXBet/edne/internal/search"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func newDirectory(t *testing.T) *directory.Directory {
+	t.Helper()
+
+	dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	return directory.New(dataset)
+}
+
+func TestDirectoryLookupCEP(t *testing.T) {
+	d := newDirectory(t)
+	require.Equal(t, 446, d.Len())
+
+	addr, ok := d.LookupCEP(6415235)
+	require.True(t, ok)
+	require.Equal(t, "São Bento", addr.Street)
+	require.Equal(t, "Mosteiro Bento", addr.City)
+
+	_, ok = d.LookupCEP(99999999)
+	require.False(t, ok)
+
+	previous, count := 0, 0
+	for zipCode, addr := range d.All() {
+		require.Greater(t, zipCode, previous)
+		require.Equal(t, zipCode, addr.ZipCode)
+		previous = zipCode
+		count++
+	}
+	require.Equal(t, 446, count)
+}
+
+func TestDirectoryLocalities(t *testing.T) {
+	d := newDirectory(t)
+
+	locations := d.LocalitiesInState("ac")
+	require.Len(t, locations, 20)
+	require.Equal(t, "Acrelândia", locations[0].Name)
+	for i := 1; i < len(locations); i++ {
+		require.LessOrEqual(t, locations[i-1].Name, locations[i].Name)
+	}
+
+	// 2@AC@Assis Brasil@69935000@0@M@@Assis Brasil@1200054
+	location, ok := d.LocalityByIBGE("1200054")
+	require.True(t, ok)
+	require.Equal(t, 2, location.ID)
+	require.Equal(t, models.LocationTypeCity, location.Type)
+
+	// Rio Branco and Rio de Janeiro share a code in the fixtures.
+	location, ok = d.LocalityByIBGE("1200401")
+	require.True(t, ok)
+	require.Equal(t, 16, location.ID)
+
+	location, ok = d.Locality(16)
+	require.True(t, ok)
+	require.Equal(t, "Rio Branco", location.Name)
+
+	_, ok = d.LocalityByIBGE("0000000")
+	require.False(t, ok)
+	require.Empty(t, d.LocalitiesInState("XX"))
+}
+
+func TestDirectoryNeighborhoodsAndStreets(t *testing.T) {
+	d := newDirectory(t)
+
+	// 41@AC@16@Placas@Placas ... 47@AC@16@Santa Quitéria@Sta Quitéria
+	neighborhoods := d.NeighborhoodsInLocality(16)
+	require.NotEmpty(t, neighborhoods)
+	for _, n := range neighborhoods {
+		require.Equal(t, 16, n.LocationID)
+	}
+	for i := 1; i < len(neighborhoods); i++ {
+		require.LessOrEqual(t, neighborhoods[i-1].Name, neighborhoods[i].Name)
+	}
+
+	// 1005314@DF@1778@1128@@SCEN Trecho 2 Conjunto 4@@70800122@Trecho@N@SCEN Tr 2 Cj 4
+	streets := d.StreetsInNeighborhood(1128)
+	require.NotEmpty(t, streets)
+	var found bool
+	for _, s := range streets {
+		require.Equal(t, 1128, s.StartingNeighborhood.ID)
+		found = found || s.ZipCode == 70800122
+	}
+	require.True(t, found)
+
+	streets = d.StreetsInLocality(1778)
+	require.NotEmpty(t, streets)
+	for i := 1; i < len(streets); i++ {
+		require.Less(t, streets[i-1].ZipCode, streets[i].ZipCode)
+	}
+
+	// Results are copies, so callers cannot corrupt the indexes.
+	streets[0].Name = "changed"
+	require.NotEqual(t, "changed", d.StreetsInLocality(1778)[0].Name)
+}
+
+func TestDirectorySearch(t *testing.T) {
+	d := newDirectory(t)
+
+	results := d.Search("getulio vargas", search.WithState("ES"))
+	require.Len(t, results, 1)
+	require.Equal(t, 29127225, results[0].Address.ZipCode)
+}
+
+func TestDirectoryMatch(t *testing.T) {
+	d := newDirectory(t)
+
+	matches := d.Match("av pres getulio varga, vitoria es")
+	require.NotEmpty(t, matches)
+	require.Equal(t, 29127225, matches[0].Address.ZipCode)
+}
+
+func TestDirectoryPostalLines(t *testing.T) {
+	d := newDirectory(t)
+
+	lines, ok := d.PostalLines(6415235, postal.WithNumber("10"))
+	require.True(t, ok)
+	require.Equal(t, []string{"Rua São Bento, 10", "Cruz de São Bento", "Mosteiro Bento - SP", "06415-235"}, lines)
+
+	lines, ok = d.PostalLines(6415235, postal.WithNumber("10"), postal.WithAbbreviate())
+	require.True(t, ok)
+	require.Equal(t, []string{"R S Bento, 10", "Cruz", "Mosteiro Bento - SP", "06415-235"}, lines)
+
+	_, ok = d.PostalLines(99999999)
+	require.False(t, ok)
+}
+
+func TestDirectoryPostalLinesLocality(t *testing.T) {
+	d := newDirectory(t)
+
+	lines, ok := d.PostalLines(69959810)
+	require.True(t, ok)
+	require.Equal(t, []string{"Terra Indígena Riozinho do Alto Envira - AC", "69959-810"}, lines)
+
+	lines, ok = d.PostalLines(69959810, postal.WithAbbreviate())
+	require.True(t, ok)
+	require.Equal(t, []string{"Terra I R At Envira - AC", "69959-810"}, lines)
+}
+
+// An address without a street, as a large user's, takes the abbreviation of
+// the locality it names.
+func TestDirectoryPostalLinesWithoutStreet(t *testing.T) {
+	d := directory.New(&models.Dataset{
+		Locations: map[int]models.Location{
+			6: {ID: 6, State: "AC", Name: "Cruzeiro do Sul", ZipCode: 69980000, Type: models.LocationTypeCity, Abbreviation: "Cruzeiro Sul", IBGECode: "1200203"},
+		},
+		Addresses: map[int]models.Address{
+			69980970: {Street: "Prefeitura", City: "Cruzeiro do Sul", CityIBGECode: "1200203", State: "AC", ZipCode: 69980970},
+		},
+	})
+
+	lines, ok := d.PostalLines(69980970, postal.WithAbbreviate())
+	require.True(t, ok)
+	require.Equal(t, []string{"Prefeitura", "Cruzeiro Sul - AC", "69980-970"}, lines)
+}
@@ -0,0 +1,377 @@
+package directory
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/search"
+)
+
+// ParsedAddress is a free-text address split into its parts. Street types,
+// localities and neighborhoods found in the eDNE are written as the eDNE
+// spells them; the street name, number and complement as typed.
+type ParsedAddress struct {
+	StreetType   string
+	Street       string
+	Number       string
+	Complement   string
+	Neighborhood string
+	City         string
+	State        string
+	// ZipCode is zero when the text has no CEP. Only a number after "CEP"
+	// or in the last part of the text is taken as one.
+	ZipCode int
+	// Address is the eDNE address the text resolves to, set when Found is
+	// true: the address of the CEP or, without one or when the eDNE lacks
+	// it, the single street section matching the other parts.
+	Address models.Address
+	Found   bool
+}
+
+var (
+	zipCodePattern  = regexp.MustCompile(`(?i)\b(cep\s*:?\s*)?(\d{2}\.?\d{3})-?(\d{3})\b`)
+	stateSlash      = regexp.MustCompile(`\s*/\s*([A-Za-z]{2})\b`)
+	partSeparators  = regexp.MustCompile(`[,;\n]|\s+[-–]\s+`)
+	numberPattern   = regexp.MustCompile(`^\d+[A-Za-z]?$`)
+	markedNumber    = regexp.MustCompile(`^(?:n|no|num|nro|numero) ?(\d+[a-z]?)$`)
+	withoutNumber   = map[string]bool{"s/n": true, "s/nº": true, "s/no": true, "sn": true, "s.n.": true}
+	numberMarkers   = map[string]bool{"n": true, "no": true, "num": true, "numero": true, "nro": true}
+	complementWords = map[string]bool{
+		"ap": true, "apt": true, "apto": true, "apartamento": true, "sala": true, "sl": true,
+		"bloco": true, "bl": true, "casa": true, "cs": true, "lote": true, "lt": true,
+		"quadra": true, "loja": true, "lj": true, "andar": true, "fundos": true, "frente": true,
+		"conjunto": true, "km": true, "torre": true, "box": true, "galpao": true,
+	}
+)
+
+// word is a word of the text as typed along with its expanded form.
+type word struct {
+	raw  string
+	norm string
+}
+
+func words(text string) []word {
+	var ws []word
+	for _, raw := range strings.Fields(text) {
+		if norm := search.Expand(raw); norm != "" || withoutNumber[strings.ToLower(raw)] {
+			ws = append(ws, word{raw: raw, norm: norm})
+		}
+	}
+	return ws
+}
+
+func join(ws []word, field func(word) string) string {
+	parts := make([]string, len(ws))
+	for i, w := range ws {
+		parts[i] = field(w)
+	}
+	return strings.Join(parts, " ")
+}
+
+func raw(w word) string  { return w.raw }
+func norm(w word) string { return w.norm }
+
+// ParseAddress splits a free-text address such as
+// "Av. Pres. Getúlio Vargas, 1200 - sala 3, Vitória/ES, 29127-225" into its
+// parts, reading street types, locality and neighborhood names from the
+// dataset, and resolves it to an eDNE address when possible.
+func (d *Directory) ParseAddress(text string) ParsedAddress {
+	d.parseOnce.Do(d.buildParseDictionaries)
+
+	var parsed ParsedAddress
+
+	if m := zipCodeMatch(text); m != nil {
+		digits := strings.ReplaceAll(text[m[4]:m[5]], ".", "") + text[m[6]:m[7]]
+		parsed.ZipCode, _ = strconv.Atoi(digits)
+		text = text[:m[0]] + "," + text[m[1]:]
+	}
+	text = stateSlash.ReplaceAllString(text, ", $1")
+
+	var parts [][]word
+	for _, part := range partSeparators.Split(text, -1) {
+		if ws := words(part); len(ws) > 0 {
+			parts = append(parts, ws)
+		}
+	}
+	if len(parts) == 0 {
+		return d.resolve(parsed)
+	}
+
+	// The UF comes last, on its own or after the city.
+	last := parts[len(parts)-1]
+	if n := len(last); isState(last[n-1]) && (n > 1 || len(parts) > 1) {
+		parsed.State = strings.ToUpper(search.Normalize(last[n-1].raw))
+		if parts[len(parts)-1] = last[:n-1]; n == 1 {
+			parts = parts[:len(parts)-1]
+		}
+	}
+
+	street, others := parts[0], parts[1:]
+	keep := d.streetTypeLength(street) + 1
+
+	// The city is a part of its own or, without one, the trailing words
+	// of the street part.
+	var neighborhoodIndex = -1
+	for i := len(others) - 1; i >= 0; i-- {
+		if location, ok := d.locality(join(others[i], norm), parsed.State); ok {
+			parsed.City = location.Name
+			parsed.State = location.State
+			others = append(others[:i:i], others[i+1:]...)
+			neighborhoodIndex = i - 1
+			break
+		}
+	}
+	if parsed.City == "" && len(others) == 0 {
+		var run []word
+		street, run = d.trailing(street, keep, func(name string) bool {
+			_, ok := d.locality(name, parsed.State)
+			return ok
+		})
+		if run != nil {
+			location, _ := d.locality(join(run, norm), parsed.State)
+			parsed.City, parsed.State = location.Name, location.State
+		}
+	}
+
+	for i, part := range others {
+		if name, ok := d.neighborhood(join(part, norm), parsed.City, parsed.State); ok {
+			parsed.Neighborhood = name
+			others = append(others[:i:i], others[i+1:]...)
+			neighborhoodIndex = -1
+			break
+		}
+	}
+	// Within the street part the neighborhood follows the number.
+	if parsed.Neighborhood == "" && len(others) == 0 {
+		var run []word
+		street, run = d.trailing(street, numberEnd(street, keep), func(name string) bool {
+			_, ok := d.neighborhood(name, parsed.City, parsed.State)
+			return ok
+		})
+		if run != nil {
+			parsed.Neighborhood, _ = d.neighborhood(join(run, norm), parsed.City, parsed.State)
+		}
+	}
+
+	var complement []string
+	if n := d.streetTypeLength(street); n > 0 {
+		parsed.StreetType = d.streetTypes[join(street[:n], norm)]
+		street = street[n:]
+	}
+	for i := 0; i < len(street); i++ {
+		if number, n := readNumber(street[i:]); n > 0 && i > 0 {
+			parsed.Number = number
+			if rest := street[i+n:]; len(rest) > 0 {
+				complement = append(complement, join(rest, raw))
+			}
+			street = street[:i]
+			break
+		}
+	}
+	parsed.Street = strings.TrimRight(join(street, raw), ".")
+
+	// Of the parts left, a number is the house number and the one just
+	// before the city, unless it reads like a complement, the neighborhood.
+	for i, part := range others {
+		if number, n := readNumber(part); n > 0 && parsed.Number == "" {
+			parsed.Number = number
+			if rest := part[n:]; len(rest) > 0 {
+				complement = append(complement, join(rest, raw))
+			}
+			continue
+		}
+		if i == neighborhoodIndex && parsed.Neighborhood == "" && !complementWords[part[0].norm] {
+			parsed.Neighborhood = join(part, raw)
+			continue
+		}
+		complement = append(complement, join(part, raw))
+	}
+	parsed.Complement = strings.Join(complement, ", ")
+
+	return d.resolve(parsed)
+}
+
+// zipCodeMatch finds the CEP of the text: the first number written after
+// "CEP" or, without one, the last that is in the last part of the text.
+// Other runs of eight digits, such as a long house number, are left out.
+func zipCodeMatch(text string) []int {
+	matches := zipCodePattern.FindAllStringSubmatchIndex(text, -1)
+	for _, m := range matches {
+		if m[2] >= 0 {
+			return m
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil
+	}
+	if m := matches[len(matches)-1]; !partSeparators.MatchString(text[m[1]:]) {
+		return m
+	}
+	return nil
+}
+
+// resolve finds the eDNE address of the parsed text: the address of its
+// CEP or, failing that, the single street section matching the street,
+// number, city and neighborhood.
+func (d *Directory) resolve(parsed ParsedAddress) ParsedAddress {
+	if parsed.ZipCode != 0 {
+		if parsed.Address, parsed.Found = d.LookupCEP(parsed.ZipCode); parsed.Found {
+			return parsed
+		}
+	}
+	if parsed.Street == "" {
+		return parsed
+	}
+
+	number, _ := strconv.Atoi(strings.TrimRightFunc(parsed.Number, unicode.IsLetter))
+	result := d.ReverseLookup(ReverseQuery{
+		StreetType: parsed.StreetType,
+		Street:     parsed.Street,
+		Number:     number,
+		City:       parsed.City,
+		State:      parsed.State,
+	})
+	if result.Unique {
+		parsed.Address, parsed.Found = result.Address, true
+		return parsed
+	}
+
+	if parsed.Neighborhood != "" {
+		var matching []models.Address
+		for _, candidate := range result.Candidates {
+			if search.Normalize(candidate.Neighborhood) == search.Normalize(parsed.Neighborhood) {
+				matching = append(matching, candidate)
+			}
+		}
+		if len(matching) == 1 {
+			parsed.Address, parsed.Found = matching[0], true
+		}
+	}
+
+	return parsed
+}
+
+func (d *Directory) buildParseDictionaries() {
+	d.streetTypes = map[string]string{}
+	for _, street := range d.dataset.Streets {
+		if key := search.Expand(street.Type); key != "" {
+			d.streetTypes[key] = street.Type
+			d.streetTypeWords = max(d.streetTypeWords, len(strings.Fields(key)))
+		}
+	}
+
+	d.localitiesByName = map[string][]models.Location{}
+	for _, location := range d.dataset.Locations {
+		key := search.Expand(location.Name)
+		d.localitiesByName[key] = append(d.localitiesByName[key], location)
+	}
+
+	d.neighborhoodsByName = map[string][]models.Neighborhood{}
+	for _, neighborhood := range d.dataset.Neighborhoods {
+		key := search.Expand(neighborhood.Name)
+		d.neighborhoodsByName[key] = append(d.neighborhoodsByName[key], neighborhood)
+	}
+	for _, neighborhoods := range d.neighborhoodsByName {
+		slices.SortFunc(neighborhoods, func(a, b models.Neighborhood) int { return a.ID - b.ID })
+	}
+}
+
+// streetTypeLength returns how many leading words of ws spell a street
+// type, preferring the longest.
+func (d *Directory) streetTypeLength(ws []word) int {
+	for n := min(d.streetTypeWords, len(ws)-1); n > 0; n-- {
+		if _, ok := d.streetTypes[join(ws[:n], norm)]; ok {
+			return n
+		}
+	}
+	return 0
+}
+
+// locality returns the locality named name, in state when given. A name
+// shared by localities of several UFs only resolves with the UF.
+func (d *Directory) locality(name, state string) (models.Location, bool) {
+	var found []models.Location
+	for _, location := range d.localitiesByName[name] {
+		if state == "" || location.State == state {
+			found = append(found, location)
+		}
+	}
+	if len(found) != 1 {
+		return models.Location{}, false
+	}
+	return found[0], true
+}
+
+// neighborhood returns the spelling of the neighborhood named name in
+// city or, failing that, in state. Neighborhoods of localities missing
+// from the dataset are only known by their UF.
+func (d *Directory) neighborhood(name, city, state string) (string, bool) {
+	neighborhoods := d.neighborhoodsByName[name]
+
+	for _, neighborhood := range neighborhoods {
+		location := d.dataset.Locations[neighborhood.LocationID]
+		if city != "" && location.Name == city && location.State == state {
+			return neighborhood.Name, true
+		}
+	}
+	for _, neighborhood := range neighborhoods {
+		if state == "" || neighborhood.State == state {
+			return neighborhood.Name, true
+		}
+	}
+	return "", false
+}
+
+// trailing splits off the longest run of trailing words, leaving at least
+// keep words, whose expanded form is accepted by match.
+func (d *Directory) trailing(ws []word, keep int, match func(string) bool) ([]word, []word) {
+	for i := keep; i < len(ws); i++ {
+		if match(join(ws[i:], norm)) {
+			return ws[:i], ws[i:]
+		}
+	}
+	return ws, nil
+}
+
+// numberEnd returns the index just past the house number among the words
+// of the street part, which starts from keep, or len(ws) when there is none.
+func numberEnd(ws []word, keep int) int {
+	for i := keep; i < len(ws); i++ {
+		if _, n := readNumber(ws[i:]); n > 0 {
+			return i + n
+		}
+	}
+	return len(ws)
+}
+
+// readNumber reads a house number at the start of ws, such as "123",
+// "nº 123", "123A" or "s/n", returning it and the words it took.
+func readNumber(ws []word) (string, int) {
+	switch {
+	case len(ws) == 0:
+		return "", 0
+	case withoutNumber[strings.ToLower(ws[0].raw)]:
+		return "s/n", 1
+	case numberPattern.MatchString(ws[0].raw):
+		return ws[0].raw, 1
+	case len(ws) > 1 && numberMarkers[ws[0].norm] && numberPattern.MatchString(ws[1].raw):
+		return ws[1].raw, 2
+	}
+
+	// "nº123" expands to "no123" and "n.123" to "n 123".
+	if m := markedNumber.FindStringSubmatch(ws[0].norm); m != nil {
+		return m[1], 1
+	}
+	return "", 0
+}
+
+// isState tells whether w is a UF. It is not expanded, as "AL" and "PE"
+// also abbreviate alameda and padre.
+func isState(w word) bool {
+	return search.IsState(search.Normalize(w.raw))
+}
@@ -0,0 +1,105 @@
+package directory_test
+
+import (
+	"testing"
+
+	"github.com/NSXBet/edne/internal/directory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddress(t *testing.T) {
+	d := newDirectory(t)
+
+	// The number picks the section of the street split across CEPs.
+	address, _ := d.LookupCEP(69919600)
+	require.Equal(t, directory.ParsedAddress{
+		StreetType: "Estrada",
+		Street:     "Dias Martins",
+		Number:     "1000",
+		Complement: "apto 12",
+		City:       "Rio Branco",
+		State:      "AC",
+		Address:    address,
+		Found:      true,
+	}, d.ParseAddress("Est. Dias Martins, nº 1000, apto 12, Rio Branco/AC"))
+
+	address, _ = d.LookupCEP(6415235)
+	require.Equal(t, directory.ParsedAddress{
+		StreetType:   "Rua",
+		Street:       "São Bento",
+		Number:       "10",
+		Neighborhood: "Cruz de São Bento",
+		City:         "Mosteiro Bento",
+		State:        "SP",
+		ZipCode:      6415235,
+		Address:      address,
+		Found:        true,
+	}, d.ParseAddress("Rua São Bento, 10 - Cruz de São Bento, Mosteiro Bento - SP, CEP 06415-235"))
+
+	// Without separators the parts are told apart by the dictionaries.
+	require.Equal(t, directory.ParsedAddress{
+		StreetType:   "Rua",
+		Street:       "s bento",
+		Number:       "10",
+		Complement:   "fundos",
+		Neighborhood: "Cruz de São Bento",
+		City:         "Mosteiro Bento",
+		State:        "SP",
+		Address:      address,
+		Found:        true,
+	}, d.ParseAddress("r s bento 10 fundos cruz de sao bento mosteiro bento sp"))
+}
+
+func TestParseAddressParts(t *testing.T) {
+	d := newDirectory(t)
+
+	parsed := d.ParseAddress("Rua Lua Azul, s/n, Placas, Rio Branco - AC")
+	require.Equal(t, "s/n", parsed.Number)
+	require.Equal(t, "Placas", parsed.Neighborhood)
+	require.True(t, parsed.Found)
+	require.Equal(t, 69909052, parsed.Address.ZipCode)
+
+	// A neighborhood missing from the eDNE is taken from its position.
+	parsed = d.ParseAddress("Rua Desconhecida 5, Bairro Novo, Rio Branco, AC")
+	require.Equal(t, "Desconhecida", parsed.Street)
+	require.Equal(t, "5", parsed.Number)
+	require.Equal(t, "Bairro Novo", parsed.Neighborhood)
+	require.Equal(t, "Rio Branco", parsed.City)
+	require.False(t, parsed.Found)
+
+	// A split street without a number does not resolve.
+	parsed = d.ParseAddress("Estrada Dias Martins, Rio Branco AC")
+	require.Empty(t, parsed.Number)
+	require.False(t, parsed.Found)
+
+	parsed = d.ParseAddress("CEP 99.999-999")
+	require.Equal(t, 99999999, parsed.ZipCode)
+	require.False(t, parsed.Found)
+
+	require.Equal(t, directory.ParsedAddress{}, d.ParseAddress("  "))
+}
+
+func TestParseAddressZipCode(t *testing.T) {
+	d := newDirectory(t)
+
+	// Eight digits ahead of the last part are not a CEP.
+	parsed := d.ParseAddress("Rua Lua Azul, 12345678, Rio Branco - AC")
+	require.Zero(t, parsed.ZipCode)
+	require.Equal(t, "12345678", parsed.Number)
+
+	parsed = d.ParseAddress("Rua Lua Azul, s/n, Placas, Rio Branco - AC 69909-052")
+	require.Equal(t, 69909052, parsed.ZipCode)
+	require.True(t, parsed.Found)
+	require.Equal(t, 69909052, parsed.Address.ZipCode)
+
+	// A number after "CEP" wins over one in the last part.
+	parsed = d.ParseAddress("Rua São Bento, CEP 06415-235, Mosteiro Bento - SP 12345678")
+	require.Equal(t, 6415235, parsed.ZipCode)
+	require.True(t, parsed.Found)
+
+	// A CEP missing from the eDNE falls back to the street.
+	parsed = d.ParseAddress("Rua São Bento, 10 - Cruz de São Bento, Mosteiro Bento - SP, CEP 99999-999")
+	require.Equal(t, 99999999, parsed.ZipCode)
+	require.True(t, parsed.Found)
+	require.Equal(t, 6415235, parsed.Address.ZipCode)
+}
@@ -0,0 +1,70 @@
+package directory
+
+import (
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/postal"
+)
+
+// PostalLines formats the address of a CEP as on envelopes, giving the
+// formatter the abbreviated names of its street, neighborhood and locality.
+// Besides street CEPs it formats the CEPs of localities without streets.
+func (d *Directory) PostalLines(zipCode int, opts ...postal.FormatOption) ([]string, bool) {
+	address, street, location, ok := d.postalAddress(zipCode)
+	if !ok {
+		return nil, false
+	}
+
+	var neighborhood models.Neighborhood
+	if street.StartingNeighborhood != nil {
+		neighborhood = d.dataset.Neighborhoods[street.StartingNeighborhood.ID]
+	}
+
+	abbreviations := postal.NewAbbreviations(street, neighborhood, location)
+	opts = append([]postal.FormatOption{postal.WithAbbreviations(abbreviations)}, opts...)
+
+	return postal.Lines(address, opts...), true
+}
+
+// postalAddress resolves a CEP by its kind, along with the street and
+// locality that hold its abbreviations. A street CEP names its locality and a
+// locality CEP is the locality itself. Any other address, such as that of a
+// large user or an operational unit, has no street of its own and its
+// locality is found by IBGE code or by name.
+func (d *Directory) postalAddress(zipCode int) (models.Address, models.Street, models.Location, bool) {
+	address, ok := d.LookupCEP(zipCode)
+	if !ok {
+		location, ok := d.localitiesByZipCode[zipCode]
+		if !ok {
+			return models.Address{}, models.Street{}, models.Location{}, false
+		}
+
+		address := models.Address{
+			City:         location.Name,
+			CityIBGECode: location.IBGECode,
+			State:        location.State,
+			ZipCode:      zipCode,
+		}
+		return address, models.Street{}, location, true
+	}
+
+	if street, ok := d.dataset.Streets[zipCode]; ok {
+		return address, street, d.dataset.Locations[street.LocationID], true
+	}
+
+	return address, models.Street{}, d.addressLocality(address), true
+}
+
+// addressLocality finds the locality an address names.
+func (d *Directory) addressLocality(address models.Address) models.Location {
+	if location, ok := d.LocalityByIBGE(address.CityIBGECode); ok && location.Name == address.City {
+		return location
+	}
+
+	for _, location := range d.localitiesByState[address.State] {
+		if location.Name == address.City {
+			return location
+		}
+	}
+
+	return models.Location{}
+}
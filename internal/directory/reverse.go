@@ -0,0 +1,220 @@
+package directory
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/search"
+)
+
+// Reason explains the outcome of a reverse lookup.
+type Reason string
+
+const (
+	// ReasonUnique means a single CEP serves the street.
+	ReasonUnique Reason = "unique"
+	// ReasonNumberInRange means the street is split across several CEPs and
+	// the number picked one of them.
+	ReasonNumberInRange Reason = "number_in_range"
+	// ReasonNumberRequired means the street is split across several CEPs
+	// and no number was given.
+	ReasonNumberRequired Reason = "number_required"
+	// ReasonNumberOutOfRange means no section of the street covers the
+	// number.
+	ReasonNumberOutOfRange Reason = "number_out_of_range"
+	// ReasonAmbiguous means several streets match and the number, if any,
+	// does not tell them apart.
+	ReasonAmbiguous Reason = "ambiguous"
+	// ReasonNotFound means no street matches.
+	ReasonNotFound Reason = "not_found"
+)
+
+// ReverseQuery is a structured address to find the CEP of. StreetType, City
+// and State are optional; Number is ignored when zero. On highways split by
+// kilometre, Number is the kilometre.
+type ReverseQuery struct {
+	StreetType string
+	Street     string
+	Number     int
+	City       string
+	State      string
+}
+
+type ReverseResult struct {
+	// Address is set when Unique is true.
+	Address    models.Address
+	Unique     bool
+	Candidates []models.Address
+	Reason     Reason
+}
+
+type section struct {
+	address models.Address
+	street  models.Street
+	// numbers is nil when the CEP serves the whole street.
+	numbers *models.NumberRange
+}
+
+// ReverseLookup finds the CEP of a structured address. Streets split across
+// several CEPs are told apart by the number, using LOG_NUM_SEC or, when the
+// street is missing from it, the range spelled out in its complement.
+func (d *Directory) ReverseLookup(q ReverseQuery) ReverseResult {
+	d.reverseOnce.Do(d.buildReverseIndex)
+
+	state := strings.ToUpper(strings.TrimSpace(q.State))
+	city := search.Normalize(q.City)
+	streetType := search.Expand(q.StreetType)
+
+	var sections []section
+	for _, s := range d.reverseIndex[search.Expand(q.Street)] {
+		if state != "" && s.address.State != state ||
+			city != "" && search.Normalize(s.address.City) != city ||
+			streetType != "" && search.Expand(s.address.StreetType) != streetType {
+			continue
+		}
+		sections = append(sections, s)
+	}
+
+	switch {
+	case len(sections) == 0:
+		return ReverseResult{Reason: ReasonNotFound}
+	case len(sections) == 1 && (q.Number == 0 || sections[0].contains(q.Number)):
+		return unique(sections[0], ReasonUnique)
+	case q.Number == 0:
+		if allNumbered(sections) {
+			return candidates(sections, ReasonNumberRequired)
+		}
+		return candidates(sections, ReasonAmbiguous)
+	}
+
+	var matching []section
+	for _, s := range sections {
+		if s.contains(q.Number) {
+			matching = append(matching, s)
+		}
+	}
+
+	switch len(matching) {
+	case 0:
+		return candidates(sections, ReasonNumberOutOfRange)
+	case 1:
+		return unique(matching[0], ReasonNumberInRange)
+	default:
+		return candidates(matching, ReasonAmbiguous)
+	}
+}
+
+func (d *Directory) buildReverseIndex() {
+	d.reverseIndex = map[string][]section{}
+
+	for _, zipCode := range d.zipCodes {
+		address := d.dataset.Addresses[zipCode]
+		street, ok := d.dataset.Streets[zipCode]
+		if !ok {
+			continue
+		}
+
+		s := section{address: address, street: street}
+		if r, ok := d.dataset.NumberRanges[street.ID]; ok {
+			s.numbers = &r
+		} else if r, ok := numberRangeFromComplement(street.Complement); ok {
+			r.StreetID = street.ID
+			s.numbers = &r
+		}
+
+		key := search.Expand(address.Street)
+		d.reverseIndex[key] = append(d.reverseIndex[key], s)
+	}
+}
+
+func (s section) contains(number int) bool {
+	return s.numbers == nil || s.numbers.Contains(number)
+}
+
+func allNumbered(sections []section) bool {
+	for _, s := range sections {
+		if s.numbers == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func unique(s section, reason Reason) ReverseResult {
+	return ReverseResult{
+		Address:    s.address,
+		Unique:     true,
+		Candidates: []models.Address{s.address},
+		Reason:     reason,
+	}
+}
+
+func candidates(sections []section, reason Reason) ReverseResult {
+	result := ReverseResult{Reason: reason}
+	for _, s := range sections {
+		result.Candidates = append(result.Candidates, s.address)
+	}
+	return result
+}
+
+// The complement of a split street spells out its section, as in
+// "- de 232 a 790 - lado par", "- até 591 - lado ímpar" or
+// "- de 7801/7802 ao fim". A pair like 7801/7802 gives the first odd and even
+// numbers of the section.
+var (
+	complementFromTo = regexp.MustCompile(`\bde (\d+)(?: (\d+))? a (\d+)(?: (\d+))?\b`)
+	complementUntil  = regexp.MustCompile(`\bate (\d+)(?: (\d+))?\b`)
+	complementToEnd  = regexp.MustCompile(`\bde (\d+)(?: (\d+))? ao fim\b`)
+	complementSide   = regexp.MustCompile(`\blado (par|impar|direito|esquerdo)\b`)
+)
+
+// lastNumber is the end of sections running "to the end" of the street.
+const lastNumber = 99999
+
+func numberRangeFromComplement(complement string) (models.NumberRange, bool) {
+	text := search.Normalize(complement)
+	r := models.NumberRange{Start: 1, End: lastNumber, Side: models.NumberSideBoth}
+
+	var found bool
+	if m := complementToEnd.FindStringSubmatch(text); m != nil {
+		r.Start, found = pairMin(m[1], m[2]), true
+	} else if m := complementFromTo.FindStringSubmatch(text); m != nil {
+		r.Start, r.End, found = pairMin(m[1], m[2]), pairMax(m[3], m[4]), true
+	} else if m := complementUntil.FindStringSubmatch(text); m != nil {
+		r.End, found = pairMax(m[1], m[2]), true
+	}
+
+	if m := complementSide.FindStringSubmatch(text); m != nil {
+		found = true
+		switch m[1] {
+		case "par":
+			r.Side = models.NumberSideEven
+		case "impar":
+			r.Side = models.NumberSideOdd
+		case "direito":
+			r.Side = models.NumberSideRight
+		case "esquerdo":
+			r.Side = models.NumberSideLeft
+		}
+	}
+
+	return r, found
+}
+
+func pairMin(a, b string) int {
+	x, _ := strconv.Atoi(a)
+	if y, err := strconv.Atoi(b); err == nil && y < x {
+		return y
+	}
+	return x
+}
+
+func pairMax(a, b string) int {
+	x, _ := strconv.Atoi(a)
+	if y, err := strconv.Atoi(b); err == nil && y > x {
+		return y
+	}
+	return x
+}
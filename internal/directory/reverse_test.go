@@ -0,0 +1,103 @@
+package directory_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/directory"
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReverseLookupSplitStreet(t *testing.T) {
+	d := newDirectory(t)
+
+	query := directory.ReverseQuery{StreetType: "Estrada", Street: "Dias Martins", City: "Rio Branco", State: "AC"}
+
+	for number, expected := range map[int]int{
+		500:  69919180, // - de 232 a 790 - lado par
+		1000: 69919600, // - de 792 a 1590 - lado par
+		501:  69915522, // - até 591 - lado ímpar
+		101:  69915522, // - até 591 - lado ímpar
+		230:  69919140, // - até 230 - lado par
+		5000: 69917520, // - de 4121/4122 a 7799/7800
+		9000: 69917766, // - de 7801/7802 ao fim
+	} {
+		query.Number = number
+		result := d.ReverseLookup(query)
+		require.True(t, result.Unique, number)
+		require.Equal(t, directory.ReasonNumberInRange, result.Reason, number)
+		require.Equal(t, expected, result.Address.ZipCode, number)
+	}
+
+	query.Number = 0
+	result := d.ReverseLookup(query)
+	require.False(t, result.Unique)
+	require.Equal(t, directory.ReasonNumberRequired, result.Reason)
+	require.Len(t, result.Candidates, 8)
+
+	// Odd numbers between 1485 and 1600 fall between sections.
+	query.Number = 1501
+	result = d.ReverseLookup(query)
+	require.Equal(t, directory.ReasonNumberOutOfRange, result.Reason)
+	require.Len(t, result.Candidates, 8)
+}
+
+func TestReverseLookupUniqueStreet(t *testing.T) {
+	d := newDirectory(t)
+
+	// 100030@ES@2044@1752@@Presidente Getúlio Vargas@@29127225@Avenida@S@Av Pres Getúlio Vargas
+	result := d.ReverseLookup(directory.ReverseQuery{
+		StreetType: "Av",
+		Street:     "Pres. Getulio Vargas",
+		Number:     120,
+		State:      "es",
+	})
+	require.True(t, result.Unique)
+	require.Equal(t, directory.ReasonUnique, result.Reason)
+	require.Equal(t, 29127225, result.Address.ZipCode)
+
+	result = d.ReverseLookup(directory.ReverseQuery{Street: "Presidente Getúlio Vargas", State: "SP"})
+	require.Equal(t, directory.ReasonNotFound, result.Reason)
+	require.Empty(t, result.Candidates)
+
+	result = d.ReverseLookup(directory.ReverseQuery{StreetType: "Rua", Street: "Presidente Getúlio Vargas"})
+	require.Equal(t, directory.ReasonNotFound, result.Reason)
+}
+
+// Highway sections are listed in kilometres, as in TestNumberRangesKilometres,
+// and stored in metres, while the query gives the kilometre.
+func TestReverseLookupKilometres(t *testing.T) {
+	dir := t.TempDir()
+	records := "953942@90,000@91,005@A\r\n953943@91,006@120,000@P\r\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "LOG_NUM_SEC.TXT"), []byte(records), 0o644))
+
+	ranges, err := parser.NewNumberRangeParser().Parse(dir, "")
+	require.NoError(t, err)
+
+	streets := map[int]models.Street{
+		88000001: {ID: 953942, ZipCode: 88000001, State: "SC", Type: "Rodovia", Name: "BR-101"},
+		88000002: {ID: 953943, ZipCode: 88000002, State: "SC", Type: "Rodovia", Name: "BR-101"},
+	}
+	d := directory.New(&models.Dataset{
+		Streets:      streets,
+		NumberRanges: ranges,
+		Addresses:    parser.JoinAddresses(streets, nil, nil),
+	})
+
+	query := directory.ReverseQuery{Street: "BR-101", State: "SC"}
+	for number, expected := range map[int]int{90: 88000001, 91: 88000001, 100: 88000002, 120: 88000002} {
+		query.Number = number
+		result := d.ReverseLookup(query)
+		require.Equal(t, directory.ReasonNumberInRange, result.Reason, number)
+		require.Equal(t, expected, result.Address.ZipCode, number)
+	}
+
+	// The side is the parity of the kilometre, not of its metres.
+	query.Number = 101
+	require.Equal(t, directory.ReasonNumberOutOfRange, d.ReverseLookup(query).Reason)
+	query.Number = 12
+	require.Equal(t, directory.ReasonNumberOutOfRange, d.ReverseLookup(query).Reason)
+}
@@ -0,0 +1,340 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/stats"
+)
+
+type Format string
+
+const (
+	FormatJSONLines Format = "jsonl"
+	FormatCSV       Format = "csv"
+)
+
+type ExportOption func(opts *ExportOptions)
+
+type ExportOptions struct {
+	Entities      bool
+	ByteOrderMark bool
+}
+
+// WithEntities makes Dataset also write the raw locations, neighborhoods and
+// streets next to the joined addresses.
+func WithEntities() ExportOption {
+	return func(opts *ExportOptions) {
+		opts.Entities = true
+	}
+}
+
+// WithByteOrderMark prefixes CSV output with a UTF-8 BOM so spreadsheet
+// applications detect the encoding.
+func WithByteOrderMark() ExportOption {
+	return func(opts *ExportOptions) {
+		opts.ByteOrderMark = true
+	}
+}
+
+type column[T any] struct {
+	name  string
+	value func(T) any
+}
+
+var addressColumns = []column[models.Address]{
+	{"cep", func(a models.Address) any { return zipCode(a.ZipCode) }},
+	{"street_type", func(a models.Address) any { return a.StreetType }},
+	{"street", func(a models.Address) any { return a.Street }},
+	{"neighborhood", func(a models.Address) any { return a.Neighborhood }},
+	{"city", func(a models.Address) any { return a.City }},
+	{"state", func(a models.Address) any { return a.State }},
+	{"city_ibge_code", func(a models.Address) any { return a.CityIBGECode }},
+}
+
+var locationColumns = []column[models.Location]{
+	{"id", func(l models.Location) any { return l.ID }},
+	{"state", func(l models.Location) any { return l.State }},
+	{"name", func(l models.Location) any { return l.Name }},
+	{"cep", func(l models.Location) any { return optionalZipCode(l.ZipCode) }},
+	{"situation", func(l models.Location) any { return int(l.Situation) }},
+	{"type", func(l models.Location) any { return string(l.Type) }},
+	{"subordinate_location_id", func(l models.Location) any { return optionalID(l.SubordinateLocationID) }},
+	{"abbreviation", func(l models.Location) any { return l.Abbreviation }},
+	{"ibge_code", func(l models.Location) any { return l.IBGECode }},
+}
+
+var neighborhoodColumns = []column[models.Neighborhood]{
+	{"id", func(n models.Neighborhood) any { return n.ID }},
+	{"state", func(n models.Neighborhood) any { return n.State }},
+	{"location_id", func(n models.Neighborhood) any { return n.LocationID }},
+	{"name", func(n models.Neighborhood) any { return n.Name }},
+	{"abbreviation", func(n models.Neighborhood) any { return n.Abbreviation }},
+}
+
+var streetColumns = []column[models.Street]{
+	{"id", func(s models.Street) any { return s.ID }},
+	{"cep", func(s models.Street) any { return zipCode(s.ZipCode) }},
+	{"state", func(s models.Street) any { return s.State }},
+	{"location_id", func(s models.Street) any { return s.LocationID }},
+	{"starting_neighborhood_id", func(s models.Street) any { return neighborhoodID(s.StartingNeighborhood) }},
+	{"ending_neighborhood_id", func(s models.Street) any { return neighborhoodID(s.EndingNeighborhood) }},
+	{"type", func(s models.Street) any { return s.Type }},
+	{"name", func(s models.Street) any { return s.Name }},
+	{"complement", func(s models.Street) any { return s.Complement }},
+	{"abbreviation", func(s models.Street) any { return s.Abbreviation }},
+}
+
+var statsColumns = []column[stats.Row]{
+	{"state", func(r stats.Row) any { return optionalText(r.State) }},
+	{"locality_id", func(r stats.Row) any { return optionalID(r.LocalityID) }},
+	{"locality", func(r stats.Row) any { return optionalText(r.Locality) }},
+	{"type", func(r stats.Row) any { return optionalText(string(r.Type)) }},
+	{"localities", func(r stats.Row) any { return r.Localities }},
+	{"neighborhoods", func(r stats.Row) any { return r.Neighborhoods }},
+	{"ceps", func(r stats.Row) any { return r.ZipCodes() }},
+	{"street_ceps", func(r stats.Row) any { return r.Streets }},
+	{"locality_ceps", func(r stats.Row) any { return r.LocalityZipCodes }},
+	{"large_user_ceps", func(r stats.Row) any { return r.LargeUsers }},
+	{"unit_ceps", func(r stats.Row) any { return r.OperationalUnits }},
+	{"cpc_ceps", func(r stats.Row) any { return r.CPCs }},
+}
+
+// Addresses writes the addresses ordered by CEP.
+func Addresses(w io.Writer, format Format, addresses map[int]models.Address, opts ...ExportOption) error {
+	return write(w, format, addressColumns, sorted(addresses), opts)
+}
+
+// ScoredAddress is an address ranked by a search, with its score.
+type ScoredAddress struct {
+	models.Address
+	Score float64
+}
+
+// ScoredAddresses writes ranked addresses in their order, each with its
+// score.
+func ScoredAddresses(w io.Writer, format Format, addresses []ScoredAddress, opts ...ExportOption) error {
+	columns := []column[ScoredAddress]{{"score", func(a ScoredAddress) any { return a.Score }}}
+	for _, c := range addressColumns {
+		columns = append(columns, column[ScoredAddress]{c.name, func(a ScoredAddress) any { return c.value(a.Address) }})
+	}
+
+	return write(w, format, columns, addresses, opts)
+}
+
+// Locations writes the locations ordered by ID.
+func Locations(w io.Writer, format Format, locations map[int]models.Location, opts ...ExportOption) error {
+	return write(w, format, locationColumns, sorted(locations), opts)
+}
+
+// Neighborhoods writes the neighborhoods ordered by ID.
+func Neighborhoods(w io.Writer, format Format, neighborhoods map[int]models.Neighborhood, opts ...ExportOption) error {
+	return write(w, format, neighborhoodColumns, sorted(neighborhoods), opts)
+}
+
+// Streets writes the streets ordered by CEP.
+func Streets(w io.Writer, format Format, streets map[int]models.Street, opts ...ExportOption) error {
+	return write(w, format, streetColumns, sorted(streets), opts)
+}
+
+// Stats writes statistics rows in the order given. Columns outside the
+// grouping of the rows are left empty.
+func Stats(w io.Writer, format Format, rows []stats.Row, opts ...ExportOption) error {
+	return write(w, format, statsColumns, rows, opts)
+}
+
+// Dataset writes addresses.<format> into dir and, when WithEntities is given,
+// locations, neighborhoods and streets files alongside it.
+func Dataset(dir string, format Format, dataset *models.Dataset, opts ...ExportOption) error {
+	options := &ExportOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("error creating directory %s: %w", dir, err)
+	}
+
+	files := []exportFile{
+		{"addresses", func(w io.Writer) error { return Addresses(w, format, dataset.Addresses, opts...) }},
+	}
+
+	if options.Entities {
+		files = append(files,
+			exportFile{"locations", func(w io.Writer) error { return Locations(w, format, dataset.Locations, opts...) }},
+			exportFile{"neighborhoods", func(w io.Writer) error {
+				return Neighborhoods(w, format, dataset.Neighborhoods, opts...)
+			}},
+			exportFile{"streets", func(w io.Writer) error { return Streets(w, format, dataset.Streets, opts...) }},
+		)
+	}
+
+	for _, f := range files {
+		filepath := path.Join(dir, f.name+"."+string(format))
+		if err := writeFile(filepath, f.write); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type exportFile struct {
+	name  string
+	write func(w io.Writer) error
+}
+
+func writeFile(filepath string, write func(w io.Writer) error) error {
+	file, err := os.Create(filepath)
+	if err != nil {
+		return fmt.Errorf("error creating file %s: %w", filepath, err)
+	}
+	defer file.Close()
+
+	if err := write(file); err != nil {
+		return fmt.Errorf("error writing file %s: %w", filepath, err)
+	}
+
+	return file.Close()
+}
+
+func write[T any](w io.Writer, format Format, columns []column[T], records []T, opts []ExportOption) error {
+	options := &ExportOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	switch format {
+	case FormatJSONLines:
+		return writeJSONLines(w, columns, records)
+	case FormatCSV:
+		return writeCSV(w, columns, records, options.ByteOrderMark)
+	default:
+		return fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+func writeJSONLines[T any](w io.Writer, columns []column[T], records []T) error {
+	buf := bufio.NewWriter(w)
+
+	for _, record := range records {
+		buf.WriteByte('{')
+		for i, col := range columns {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			key, _ := json.Marshal(col.name)
+			value, err := json.Marshal(col.value(record))
+			if err != nil {
+				return fmt.Errorf("error encoding %s: %w", col.name, err)
+			}
+
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteString("}\n")
+	}
+
+	return buf.Flush()
+}
+
+func writeCSV[T any](w io.Writer, columns []column[T], records []T, bom bool) error {
+	if bom {
+		if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+			return err
+		}
+	}
+
+	writer := csv.NewWriter(w)
+
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.name
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	row := make([]string, len(columns))
+	for _, record := range records {
+		for i, col := range columns {
+			row[i] = formatValue(col.value(record))
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func sorted[T any](m map[int]T) []T {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	records := make([]T, len(keys))
+	for i, k := range keys {
+		records[i] = m[k]
+	}
+
+	return records
+}
+
+func zipCode(zipCode int) string {
+	return fmt.Sprintf("%08d", zipCode)
+}
+
+func optionalZipCode(zipCode int) any {
+	if zipCode == 0 {
+		return nil
+	}
+	return fmt.Sprintf("%08d", zipCode)
+}
+
+func optionalText(text string) any {
+	if text == "" {
+		return nil
+	}
+	return text
+}
+
+func optionalID(id int) any {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+func neighborhoodID(n *models.Neighborhood) any {
+	if n == nil {
+		return nil
+	}
+	return optionalID(n.ID)
+}
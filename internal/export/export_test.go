@@ -0,0 +1,102 @@
+package export_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/export"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/internal/stats"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportAddressesCSV(t *testing.T) {
+	dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, export.Addresses(&buf, export.FormatCSV, dataset.Addresses))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 447)
+	require.Equal(t, []string{"cep", "street_type", "street", "neighborhood", "city", "state", "city_ibge_code"}, records[0])
+
+	// Rows are ordered by CEP, so the smallest CEP comes first.
+	for i := 2; i < len(records); i++ {
+		require.Less(t, records[i-1][0], records[i][0])
+	}
+
+	var found bool
+	for _, record := range records[1:] {
+		if record[0] == "06415235" {
+			found = true
+			require.Equal(t, []string{"06415235", "Rua", "São Bento", "Cruz de São Bento", "Mosteiro Bento", "SP", "06415235"}, record)
+		}
+	}
+	require.True(t, found)
+}
+
+func TestExportAddressesJSONLines(t *testing.T) {
+	dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, export.Addresses(&buf, export.FormatJSONLines, dataset.Addresses))
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 446)
+	require.True(t, strings.HasPrefix(lines[0], `{"cep":`))
+
+	var record map[string]string
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	require.Len(t, record, 7)
+	require.Len(t, record["cep"], 8)
+}
+
+func TestExportDatasetWithEntities(t *testing.T) {
+	dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, export.Dataset(dir, export.FormatCSV, dataset, export.WithEntities(), export.WithByteOrderMark()))
+
+	for _, name := range []string{"addresses", "locations", "neighborhoods", "streets"} {
+		content, err := os.ReadFile(filepath.Join(dir, name+".csv"))
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(content, []byte("\uFEFF")), name)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "locations.csv"))
+	require.NoError(t, err)
+	// 16@AC@Rio Branco@@1@M@@Rio Branco@1200401
+	require.Contains(t, string(content), "\n16,AC,Rio Branco,,1,M,,Rio Branco,1200401\n")
+}
+
+func TestExportStatsCSV(t *testing.T) {
+	dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, export.Stats(&buf, export.FormatCSV, stats.New(dataset).ByState()))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 17)
+	require.Equal(t, []string{
+		"state", "locality_id", "locality", "type", "localities", "neighborhoods",
+		"ceps", "street_ceps", "locality_ceps", "large_user_ceps", "unit_ceps", "cpc_ceps",
+	}, records[0])
+	require.Equal(t, []string{"AC", "", "", "", "20", "6", "30", "11", "19", "0", "0", "0"}, records[1])
+}
+
+func TestExportUnsupportedFormat(t *testing.T) {
+	err := export.Neighborhoods(&bytes.Buffer{}, export.Format("xml"), nil)
+	require.Error(t, err)
+}
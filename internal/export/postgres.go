@@ -0,0 +1,435 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NSXBet/edne/internal/models"
+)
+
+const postgresSchema = `CREATE TABLE IF NOT EXISTS localities (
+	id             integer PRIMARY KEY,
+	state          char(2)      NOT NULL,
+	name           varchar(72)  NOT NULL,
+	cep            char(8),
+	situation      smallint     NOT NULL,
+	type           char(1)      NOT NULL,
+	subordinate_id integer REFERENCES localities (id),
+	abbreviation   varchar(36),
+	ibge_code      char(7)
+);
+
+CREATE TABLE IF NOT EXISTS neighborhoods (
+	id           integer PRIMARY KEY,
+	state        char(2)     NOT NULL,
+	locality_id  integer REFERENCES localities (id),
+	name         varchar(72) NOT NULL,
+	abbreviation varchar(36)
+);
+
+CREATE TABLE IF NOT EXISTS streets (
+	id                       integer PRIMARY KEY,
+	cep                      char(8)      NOT NULL UNIQUE,
+	state                    char(2)      NOT NULL,
+	locality_id              integer REFERENCES localities (id),
+	starting_neighborhood_id integer REFERENCES neighborhoods (id),
+	ending_neighborhood_id   integer REFERENCES neighborhoods (id),
+	type                     varchar(36)  NOT NULL,
+	use_type                 boolean      NOT NULL,
+	name                     varchar(100) NOT NULL,
+	complement               varchar(100),
+	abbreviation             varchar(36)
+);
+
+CREATE TABLE IF NOT EXISTS addresses (
+	cep            char(8) PRIMARY KEY,
+	street_id      integer REFERENCES streets (id),
+	street_type    varchar(36),
+	street         varchar(100),
+	neighborhood   varchar(72),
+	city           varchar(72),
+	state          char(2),
+	city_ibge_code char(7)
+);
+
+CREATE INDEX IF NOT EXISTS localities_state_name ON localities (state, name);
+CREATE INDEX IF NOT EXISTS localities_ibge_code ON localities (ibge_code);
+CREATE INDEX IF NOT EXISTS neighborhoods_locality_id ON neighborhoods (locality_id);
+CREATE INDEX IF NOT EXISTS neighborhoods_name ON neighborhoods (name);
+CREATE INDEX IF NOT EXISTS streets_locality_id ON streets (locality_id);
+CREATE INDEX IF NOT EXISTS streets_starting_neighborhood_id ON streets (starting_neighborhood_id);
+CREATE INDEX IF NOT EXISTS streets_name ON streets (name);
+CREATE INDEX IF NOT EXISTS addresses_street_id ON addresses (street_id);
+CREATE INDEX IF NOT EXISTS addresses_state_city ON addresses (state, city);
+`
+
+var (
+	postgresLocalityColumns = []string{
+		"id", "state", "name", "cep", "situation", "type", "subordinate_id", "abbreviation", "ibge_code",
+	}
+	postgresNeighborhoodColumns = []string{"id", "state", "locality_id", "name", "abbreviation"}
+	postgresStreetColumns       = []string{
+		"id", "cep", "state", "locality_id", "starting_neighborhood_id", "ending_neighborhood_id",
+		"type", "use_type", "name", "complement", "abbreviation",
+	}
+	postgresAddressColumns = []string{
+		"cep", "street_id", "street_type", "street", "neighborhood", "city", "state", "city_ibge_code",
+	}
+)
+
+// addressesFromTables rebuilds addresses the same way MasterParser joins them.
+const addressesFromTables = `SELECT s.cep, s.id, s.type, s.name,
+	coalesce(n.name, ''), coalesce(l.name, ''), coalesce(l.state, s.state), l.ibge_code
+FROM streets s
+LEFT JOIN neighborhoods n ON n.id = s.starting_neighborhood_id
+LEFT JOIN localities l ON l.id = s.locality_id`
+
+// sqlExpression is written verbatim instead of being quoted as a literal.
+type sqlExpression string
+
+// PostgresSchema writes the DDL for the localities, neighborhoods, streets
+// and addresses tables.
+func PostgresSchema(w io.Writer) error {
+	_, err := io.WriteString(w, postgresSchema)
+	return err
+}
+
+// Postgres writes a psql script creating the schema and bulk loading the
+// dataset with COPY.
+func Postgres(w io.Writer, dataset *models.Dataset) error {
+	if err := PostgresSchema(w); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return err
+	}
+
+	return PostgresCopy(w, dataset)
+}
+
+// PostgresCopy writes one COPY ... FROM stdin block per table, in foreign key
+// order. References to entities missing from the release are written as NULL.
+func PostgresCopy(w io.Writer, dataset *models.Dataset) error {
+	buf := bufio.NewWriter(w)
+
+	writeCopyHeader(buf, "localities", postgresLocalityColumns)
+	for _, l := range localitiesInDependencyOrder(dataset.Locations) {
+		writeCopyRow(buf, localityValues(l, reference(dataset.Locations, l.SubordinateLocationID)))
+	}
+	writeCopyTrailer(buf)
+
+	writeCopyHeader(buf, "neighborhoods", postgresNeighborhoodColumns)
+	for _, n := range sorted(dataset.Neighborhoods) {
+		writeCopyRow(buf, neighborhoodValues(n, reference(dataset.Locations, n.LocationID)))
+	}
+	writeCopyTrailer(buf)
+
+	writeCopyHeader(buf, "streets", postgresStreetColumns)
+	for _, s := range sorted(dataset.Streets) {
+		writeCopyRow(buf, streetValues(s,
+			reference(dataset.Locations, s.LocationID),
+			neighborhoodReference(dataset.Neighborhoods, s.StartingNeighborhood),
+			neighborhoodReference(dataset.Neighborhoods, s.EndingNeighborhood)))
+	}
+	writeCopyTrailer(buf)
+
+	writeCopyHeader(buf, "addresses", postgresAddressColumns)
+	for _, a := range sorted(dataset.Addresses) {
+		var streetID any
+		if street, ok := dataset.Streets[a.ZipCode]; ok {
+			streetID = street.ID
+		}
+		writeCopyRow(buf, []any{
+			zipCode(a.ZipCode), streetID, a.StreetType, a.Street, a.Neighborhood, a.City, a.State,
+			nullString(a.CityIBGECode),
+		})
+	}
+	writeCopyTrailer(buf)
+
+	return buf.Flush()
+}
+
+// PostgresDelta writes a transaction applying delta to tables created by
+// PostgresSchema. Addresses touched by the changed entities are rebuilt from
+// the tables at the end of the transaction.
+func PostgresDelta(w io.Writer, delta *models.Delta) error {
+	buf := bufio.NewWriter(w)
+
+	var streetIDs, neighborhoodIDs, locationIDs []string
+	for _, change := range delta.Streets {
+		streetIDs = append(streetIDs, strconv.Itoa(change.Street.ID))
+	}
+	for _, change := range delta.Neighborhoods {
+		neighborhoodIDs = append(neighborhoodIDs, strconv.Itoa(change.Neighborhood.ID))
+	}
+	for _, change := range delta.Locations {
+		locationIDs = append(locationIDs, strconv.Itoa(change.Location.ID))
+	}
+
+	var affected []string
+	if len(streetIDs) > 0 {
+		affected = append(affected, "s.id IN ("+strings.Join(streetIDs, ", ")+")")
+	}
+	if len(neighborhoodIDs) > 0 {
+		affected = append(affected, "s.starting_neighborhood_id IN ("+strings.Join(neighborhoodIDs, ", ")+")")
+	}
+	if len(locationIDs) > 0 {
+		affected = append(affected, "s.locality_id IN ("+strings.Join(locationIDs, ", ")+")")
+	}
+	condition := strings.Join(affected, "\n\tOR ")
+
+	buf.WriteString("BEGIN;\n\n")
+
+	if condition != "" {
+		fmt.Fprintf(buf, "DELETE FROM addresses WHERE street_id IN (SELECT s.id FROM streets s WHERE %s);\n\n", condition)
+	}
+
+	// Deletions come first: a street may be replaced by another one with the
+	// same CEP, and references to deleted rows are cleared as the release
+	// would leave them.
+	var deletedNeighborhoods, deletedLocations []string
+	for _, change := range delta.Streets {
+		if change.Operation == models.OperationDelete {
+			fmt.Fprintf(buf, "DELETE FROM streets WHERE id = %d;\n", change.Street.ID)
+		}
+	}
+
+	for _, change := range delta.Neighborhoods {
+		if change.Operation == models.OperationDelete {
+			deletedNeighborhoods = append(deletedNeighborhoods, strconv.Itoa(change.Neighborhood.ID))
+		}
+	}
+	if len(deletedNeighborhoods) > 0 {
+		ids := strings.Join(deletedNeighborhoods, ", ")
+		writeClearReferences(buf, "streets", "starting_neighborhood_id", ids)
+		writeClearReferences(buf, "streets", "ending_neighborhood_id", ids)
+		for _, id := range deletedNeighborhoods {
+			fmt.Fprintf(buf, "DELETE FROM neighborhoods WHERE id = %s;\n", id)
+		}
+	}
+
+	for _, change := range delta.Locations {
+		if change.Operation == models.OperationDelete {
+			deletedLocations = append(deletedLocations, strconv.Itoa(change.Location.ID))
+		}
+	}
+	if len(deletedLocations) > 0 {
+		ids := strings.Join(deletedLocations, ", ")
+		writeClearReferences(buf, "localities", "subordinate_id", ids)
+		writeClearReferences(buf, "neighborhoods", "locality_id", ids)
+		writeClearReferences(buf, "streets", "locality_id", ids)
+		for _, id := range deletedLocations {
+			fmt.Fprintf(buf, "DELETE FROM localities WHERE id = %s;\n", id)
+		}
+	}
+
+	// Rows are written without their references, which are resolved once
+	// every row of the delta exists: a record may refer to one inserted
+	// further down, such as a locality subordinate to a new one.
+	for _, change := range delta.Locations {
+		if change.Operation == models.OperationDelete {
+			continue
+		}
+		values := localityValues(change.Location, nil)
+		writeUpsert(buf, change.Operation, "localities", postgresLocalityColumns, values)
+	}
+
+	for _, change := range delta.Neighborhoods {
+		if change.Operation == models.OperationDelete {
+			continue
+		}
+		values := neighborhoodValues(change.Neighborhood, nil)
+		writeUpsert(buf, change.Operation, "neighborhoods", postgresNeighborhoodColumns, values)
+	}
+
+	for _, change := range delta.Streets {
+		if change.Operation == models.OperationDelete {
+			continue
+		}
+		values := streetValues(change.Street, nil, nil, nil)
+		writeUpsert(buf, change.Operation, "streets", postgresStreetColumns, values)
+	}
+
+	for _, change := range delta.Locations {
+		if change.Operation == models.OperationDelete {
+			continue
+		}
+		l := change.Location
+		writeReferences(buf, "localities", l.ID, []string{"subordinate_id"},
+			existing("localities", l.SubordinateLocationID))
+	}
+
+	for _, change := range delta.Neighborhoods {
+		if change.Operation == models.OperationDelete {
+			continue
+		}
+		n := change.Neighborhood
+		writeReferences(buf, "neighborhoods", n.ID, []string{"locality_id"}, existing("localities", n.LocationID))
+	}
+
+	for _, change := range delta.Streets {
+		if change.Operation == models.OperationDelete {
+			continue
+		}
+		s := change.Street
+		writeReferences(buf, "streets", s.ID,
+			[]string{"locality_id", "starting_neighborhood_id", "ending_neighborhood_id"},
+			existing("localities", s.LocationID),
+			existingNeighborhood(s.StartingNeighborhood),
+			existingNeighborhood(s.EndingNeighborhood))
+	}
+
+	// Streets whose references were cleared no longer match the condition,
+	// so every street left without an address is rebuilt.
+	if condition != "" {
+		fmt.Fprintf(buf, "\nINSERT INTO addresses (%s)\n%s\nWHERE NOT EXISTS (SELECT 1 FROM addresses a WHERE a.cep = s.cep);\n",
+			strings.Join(postgresAddressColumns, ", "), addressesFromTables)
+	}
+
+	buf.WriteString("\nCOMMIT;\n")
+
+	return buf.Flush()
+}
+
+func localityValues(l models.Location, subordinate any) []any {
+	return []any{
+		l.ID, l.State, l.Name, optionalZipCode(l.ZipCode), int(l.Situation), string(l.Type), subordinate,
+		nullString(l.Abbreviation), nullString(l.IBGECode),
+	}
+}
+
+func neighborhoodValues(n models.Neighborhood, location any) []any {
+	return []any{n.ID, n.State, location, n.Name, nullString(n.Abbreviation)}
+}
+
+func streetValues(s models.Street, location, starting, ending any) []any {
+	return []any{
+		s.ID, zipCode(s.ZipCode), s.State, location, starting, ending, s.Type, s.UseType, s.Name,
+		nullString(s.Complement), nullString(s.Abbreviation),
+	}
+}
+
+// localitiesInDependencyOrder orders localities so that every subordinate
+// locality comes after the one it refers to, as COPY checks foreign keys row
+// by row.
+func localitiesInDependencyOrder(locations map[int]models.Location) []models.Location {
+	depth := func(l models.Location) int {
+		d := 0
+		for seen := map[int]bool{l.ID: true}; ; d++ {
+			parent, ok := locations[l.SubordinateLocationID]
+			if !ok || seen[parent.ID] {
+				return d
+			}
+			seen[parent.ID] = true
+			l = parent
+		}
+	}
+
+	ordered := sorted(locations)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return depth(ordered[i]) < depth(ordered[j])
+	})
+
+	return ordered
+}
+
+func writeCopyHeader(buf *bufio.Writer, table string, columns []string) {
+	fmt.Fprintf(buf, "COPY %s (%s) FROM stdin;\n", table, strings.Join(columns, ", "))
+}
+
+func writeCopyTrailer(buf *bufio.Writer) {
+	buf.WriteString("\\.\n\n")
+}
+
+var copyEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+func writeCopyRow(buf *bufio.Writer, values []any) {
+	for i, v := range values {
+		if i > 0 {
+			buf.WriteByte('\t')
+		}
+		if v == nil {
+			buf.WriteString(`\N`)
+			continue
+		}
+		buf.WriteString(copyEscaper.Replace(formatValue(v)))
+	}
+	buf.WriteByte('\n')
+}
+
+func writeClearReferences(buf *bufio.Writer, table, column, ids string) {
+	fmt.Fprintf(buf, "UPDATE %s SET %s = NULL WHERE %s IN (%s);\n", table, column, column, ids)
+}
+
+// writeReferences sets the reference columns of the row id of table, unless
+// every value is NULL, which the upsert already wrote.
+func writeReferences(buf *bufio.Writer, table string, id int, columns []string, values ...any) {
+	var assignments []string
+	for i, v := range values {
+		if v != nil {
+			assignments = append(assignments, columns[i]+" = "+sqlLiteral(v))
+		}
+	}
+	if len(assignments) == 0 {
+		return
+	}
+
+	fmt.Fprintf(buf, "UPDATE %s SET %s WHERE id = %d;\n", table, strings.Join(assignments, ", "), id)
+}
+
+func writeUpsert(buf *bufio.Writer, op models.Operation, table string, columns []string, values []any) {
+	literals := make([]string, len(values))
+	for i, v := range values {
+		literals[i] = sqlLiteral(v)
+	}
+
+	if op == models.OperationInsert {
+		fmt.Fprintf(buf, "INSERT INTO %s (%s) VALUES (%s);\n",
+			table, strings.Join(columns, ", "), strings.Join(literals, ", "))
+		return
+	}
+
+	// The first column is always the primary key.
+	assignments := make([]string, 0, len(columns)-1)
+	for i := 1; i < len(columns); i++ {
+		assignments = append(assignments, columns[i]+" = "+literals[i])
+	}
+	fmt.Fprintf(buf, "UPDATE %s SET %s WHERE %s = %s;\n",
+		table, strings.Join(assignments, ", "), columns[0], literals[0])
+}
+
+func sqlLiteral(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case sqlExpression:
+		return string(v)
+	case int:
+		return strconv.Itoa(v)
+	case bool:
+		return strings.ToUpper(strconv.FormatBool(v))
+	default:
+		return "'" + strings.ReplaceAll(formatValue(v), "'", "''") + "'"
+	}
+}
+
+// existing refers to id only when the row is present in the target database,
+// since a delta alone cannot tell whether the referenced entity was loaded.
+func existing(table string, id int) any {
+	if id == 0 {
+		return nil
+	}
+	return sqlExpression(fmt.Sprintf("(SELECT id FROM %s WHERE id = %d)", table, id))
+}
+
+func existingNeighborhood(n *models.Neighborhood) any {
+	if n == nil {
+		return nil
+	}
+	return existing("neighborhoods", n.ID)
+}
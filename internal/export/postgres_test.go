@@ -0,0 +1,239 @@
+package export_test
+
+import (
+	"bytes"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/export"
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportPostgres(t *testing.T) {
+	dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, export.Postgres(&buf, dataset))
+
+	script := buf.String()
+	for _, table := range []string{"localities", "neighborhoods", "streets", "addresses"} {
+		require.Contains(t, script, "CREATE TABLE IF NOT EXISTS "+table+" (")
+	}
+
+	rows := map[string][]string{}
+	var table string
+	for _, line := range strings.Split(script, "\n") {
+		switch {
+		case strings.HasPrefix(line, "COPY "):
+			table = strings.Fields(line)[1]
+		case line == `\.`:
+			table = ""
+		case table != "":
+			rows[table] = append(rows[table], line)
+		}
+	}
+
+	require.Len(t, rows["localities"], len(dataset.Locations))
+	require.Len(t, rows["neighborhoods"], len(dataset.Neighborhoods))
+	require.Len(t, rows["streets"], len(dataset.Streets))
+	require.Len(t, rows["addresses"], 446)
+
+	// 16@AC@Rio Branco@@1@M@@Rio Branco@1200401
+	require.Contains(t, rows["localities"], "16\tAC\tRio Branco\t\\N\t1\tM\t\\N\tRio Branco\t1200401")
+
+	// Subordinate localities are loaded after the locality they refer to.
+	position := map[string]int{}
+	for i, row := range rows["localities"] {
+		position[strings.Split(row, "\t")[0]] = i
+	}
+	for i, row := range rows["localities"] {
+		if parent := strings.Split(row, "\t")[6]; parent != `\N` {
+			require.Less(t, position[parent], i)
+		}
+	}
+}
+
+func TestExportPostgresDelta(t *testing.T) {
+	delta, err := parser.NewDeltaParser().Parse(test.Fixture("update"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, export.PostgresDelta(&buf, delta))
+
+	script := buf.String()
+	require.True(t, strings.HasPrefix(script, "BEGIN;\n"))
+	require.True(t, strings.HasSuffix(script, "COMMIT;\n"))
+	require.Equal(t, 281, strings.Count(script, "INSERT INTO streets "))
+	require.Equal(t, 101, strings.Count(script, "UPDATE streets SET cep = "))
+	require.Equal(t, 44, strings.Count(script, "DELETE FROM streets WHERE id = "))
+	require.Equal(t, 28, strings.Count(script, "INSERT INTO neighborhoods "))
+	require.Equal(t, 5, strings.Count(script, "UPDATE neighborhoods SET state = "))
+
+	// Addresses are removed before the streets they reference and rebuilt last.
+	require.Less(t, strings.Index(script, "DELETE FROM addresses"), strings.Index(script, "DELETE FROM streets"))
+	require.Greater(t, strings.Index(script, "INSERT INTO addresses"), strings.LastIndex(script, "DELETE FROM streets"))
+
+	// 948781@AC@16@55415@@da Alegria@@69908654@Travessa@S@Tv da Alegria@DEL@
+	require.Contains(t, script, "DELETE FROM streets WHERE id = 948781;\n")
+}
+
+// TestExportPostgresDeltaApply runs the delta against the base release
+// loaded into SQLite, which enforces the same UNIQUE and foreign key
+// constraints statement by statement.
+func TestExportPostgresDeltaApply(t *testing.T) {
+	base, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), "")
+	require.NoError(t, err)
+	updated, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+	delta, err := parser.NewDeltaParser().Parse(test.Fixture("update"))
+	require.NoError(t, err)
+
+	// The sample base leaves out most rows the delta deletes. They are put
+	// back so that the deletions, and the CEPs they free, take effect.
+	for _, change := range delta.Locations {
+		if change.Operation == models.OperationDelete {
+			base.Locations[change.Location.ID] = change.Location
+		}
+	}
+	for _, change := range delta.Neighborhoods {
+		if change.Operation == models.OperationDelete {
+			base.Neighborhoods[change.Neighborhood.ID] = change.Neighborhood
+		}
+	}
+	for _, change := range delta.Streets {
+		if change.Operation == models.OperationDelete {
+			base.Streets[change.Street.ZipCode] = change.Street
+		}
+	}
+	base.Addresses = parser.JoinAddresses(base.Streets, base.Neighborhoods, base.Locations)
+
+	path := filepath.Join(t.TempDir(), "edne.db")
+	require.NoError(t, export.SQLite(path, base))
+
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)")
+	require.NoError(t, err)
+	defer db.Close()
+
+	var buf bytes.Buffer
+	require.NoError(t, export.PostgresDelta(&buf, delta))
+	_, err = db.Exec(buf.String())
+	require.NoError(t, err)
+
+	rows, err := db.Query("PRAGMA foreign_key_check")
+	require.NoError(t, err)
+	require.False(t, rows.Next(), "foreign key violations found")
+	require.NoError(t, rows.Close())
+
+	// The sample delta also updates streets left out of the sample base,
+	// which UPDATE skips, so only the rows present are compared.
+	addresses, err := db.Query(`SELECT cep, street, neighborhood, city, state, coalesce(city_ibge_code, '') FROM addresses`)
+	require.NoError(t, err)
+	defer addresses.Close()
+
+	var count int
+	for addresses.Next() {
+		var cep int
+		var a models.Address
+		require.NoError(t, addresses.Scan(&cep, &a.Street, &a.Neighborhood, &a.City, &a.State, &a.CityIBGECode))
+
+		expected, ok := updated.Addresses[cep]
+		require.True(t, ok, cep)
+		require.Equal(t, expected.Street, a.Street, cep)
+		require.Equal(t, expected.Neighborhood, a.Neighborhood, cep)
+		require.Equal(t, expected.City, a.City, cep)
+		require.Equal(t, expected.State, a.State, cep)
+		require.Equal(t, expected.CityIBGECode, a.CityIBGECode, cep)
+		count++
+	}
+	require.NoError(t, addresses.Err())
+
+	var streets int
+	require.NoError(t, db.QueryRow("SELECT count(*) FROM streets").Scan(&streets))
+	require.Equal(t, streets, count)
+
+	// 18990452 moved from street 1303839, deleted, to 1303840.
+	var streetID int
+	require.NoError(t, db.QueryRow(`SELECT street_id FROM addresses WHERE cep = '18990452'`).Scan(&streetID))
+	require.Equal(t, updated.Streets[18990452].ID, streetID)
+}
+
+func TestExportPostgresDeltaQuoting(t *testing.T) {
+	delta := &models.Delta{
+		Neighborhoods: []models.NeighborhoodChange{{
+			Operation:    models.OperationInsert,
+			Neighborhood: models.Neighborhood{ID: 1, State: "SP", LocationID: 2, Name: "Olho d'Água"},
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, export.PostgresDelta(&buf, delta))
+	require.Contains(t, buf.String(),
+		"INSERT INTO neighborhoods (id, state, locality_id, name, abbreviation) "+
+			"VALUES (1, 'SP', NULL, 'Olho d''Água', NULL);\n"+
+			"UPDATE neighborhoods SET locality_id = (SELECT id FROM localities WHERE id = 2) WHERE id = 1;\n")
+}
+
+// References to rows inserted by the same delta hold, wherever the rows
+// come in it.
+func TestExportPostgresDeltaReferences(t *testing.T) {
+	base, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), "")
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "edne.db")
+	require.NoError(t, export.SQLite(path, base))
+
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)")
+	require.NoError(t, err)
+	defer db.Close()
+
+	delta := &models.Delta{
+		Locations: []models.LocationChange{
+			{Operation: models.OperationInsert, Location: models.Location{
+				ID: 900001, State: "AC", Name: "Vila Nova", Type: models.LocationTypeDistrict,
+				SubordinateLocationID: 900002,
+			}},
+			{Operation: models.OperationInsert, Location: models.Location{
+				ID: 900002, State: "AC", Name: "Cidade Nova", Type: models.LocationTypeCity, IBGECode: "1200999",
+			}},
+		},
+		Neighborhoods: []models.NeighborhoodChange{
+			{Operation: models.OperationInsert, Neighborhood: models.Neighborhood{
+				ID: 900003, State: "AC", LocationID: 900002, Name: "Centro",
+			}},
+		},
+		Streets: []models.StreetChange{
+			{Operation: models.OperationInsert, Street: models.Street{
+				ID: 900004, ZipCode: 69999001, State: "AC", LocationID: 900002,
+				StartingNeighborhood: &models.Neighborhood{ID: 900003}, Type: "Rua", Name: "Nova",
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, export.PostgresDelta(&buf, delta))
+	_, err = db.Exec(buf.String())
+	require.NoError(t, err)
+
+	var subordinate, neighborhoodLocality, streetLocality, starting int
+	require.NoError(t, db.QueryRow(`SELECT subordinate_id FROM localities WHERE id = 900001`).Scan(&subordinate))
+	require.Equal(t, 900002, subordinate)
+	require.NoError(t, db.QueryRow(`SELECT locality_id FROM neighborhoods WHERE id = 900003`).Scan(&neighborhoodLocality))
+	require.Equal(t, 900002, neighborhoodLocality)
+	require.NoError(t, db.QueryRow(`SELECT locality_id, starting_neighborhood_id FROM streets WHERE id = 900004`).
+		Scan(&streetLocality, &starting))
+	require.Equal(t, 900002, streetLocality)
+	require.Equal(t, 900003, starting)
+
+	var neighborhood, city, ibgeCode string
+	require.NoError(t, db.QueryRow(`SELECT neighborhood, city, city_ibge_code FROM addresses WHERE cep = '69999001'`).
+		Scan(&neighborhood, &city, &ibgeCode))
+	require.Equal(t, "Centro", neighborhood)
+	require.Equal(t, "Cidade Nova", city)
+	require.Equal(t, "1200999", ibgeCode)
+}
@@ -0,0 +1,256 @@
+package export
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/NSXBet/edne/internal/models"
+	_ "modernc.org/sqlite" // pure-Go driver, registered as "sqlite"
+)
+
+const sqliteSchema = `
+CREATE TABLE localities (
+	id             INTEGER PRIMARY KEY,
+	state          TEXT    NOT NULL,
+	name           TEXT    NOT NULL,
+	cep            TEXT,
+	situation      INTEGER NOT NULL,
+	type           TEXT    NOT NULL,
+	subordinate_id INTEGER REFERENCES localities (id),
+	abbreviation   TEXT,
+	ibge_code      TEXT
+);
+
+CREATE TABLE neighborhoods (
+	id           INTEGER PRIMARY KEY,
+	state        TEXT NOT NULL,
+	locality_id  INTEGER REFERENCES localities (id),
+	name         TEXT NOT NULL,
+	abbreviation TEXT
+);
+
+CREATE TABLE streets (
+	id                       INTEGER PRIMARY KEY,
+	cep                      TEXT NOT NULL UNIQUE,
+	state                    TEXT NOT NULL,
+	locality_id              INTEGER REFERENCES localities (id),
+	starting_neighborhood_id INTEGER REFERENCES neighborhoods (id),
+	ending_neighborhood_id   INTEGER REFERENCES neighborhoods (id),
+	type                     TEXT    NOT NULL,
+	use_type                 INTEGER NOT NULL,
+	name                     TEXT    NOT NULL,
+	complement               TEXT,
+	abbreviation             TEXT
+);
+
+CREATE TABLE addresses (
+	cep            TEXT PRIMARY KEY,
+	street_id      INTEGER REFERENCES streets (id),
+	street_type    TEXT,
+	street         TEXT,
+	neighborhood   TEXT,
+	city           TEXT,
+	state          TEXT,
+	city_ibge_code TEXT
+);
+
+CREATE INDEX localities_state_name ON localities (state, name);
+CREATE INDEX localities_ibge_code ON localities (ibge_code);
+CREATE INDEX neighborhoods_locality_id ON neighborhoods (locality_id);
+CREATE INDEX neighborhoods_name ON neighborhoods (name);
+CREATE INDEX streets_locality_id ON streets (locality_id);
+CREATE INDEX streets_starting_neighborhood_id ON streets (starting_neighborhood_id);
+CREATE INDEX streets_name ON streets (name);
+CREATE INDEX addresses_street_id ON addresses (street_id);
+CREATE INDEX addresses_state_city ON addresses (state, city);
+
+CREATE VIRTUAL TABLE streets_fts USING fts5 (
+	type,
+	name,
+	complement,
+	content = 'streets',
+	content_rowid = 'id',
+	tokenize = 'unicode61 remove_diacritics 2'
+);
+`
+
+// SQLite writes the dataset into a new SQLite database at filepath, replacing
+// any existing file. References to entities missing from the release are
+// stored as NULL so the foreign keys always hold.
+func SQLite(filepath string, dataset *models.Dataset) error {
+	if err := os.Remove(filepath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error removing file %s: %w", filepath, err)
+	}
+
+	dsn := url.URL{Scheme: "file", OmitHost: true, Path: filepath, RawQuery: "_pragma=foreign_keys(1)"}
+	db, err := sql.Open("sqlite", dsn.String())
+	if err != nil {
+		return fmt.Errorf("error opening database %s: %w", filepath, err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		return fmt.Errorf("error creating schema: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := insertLocalities(tx, dataset.Locations); err != nil {
+		return fmt.Errorf("error inserting localities: %w", err)
+	}
+
+	if err := insertNeighborhoods(tx, dataset); err != nil {
+		return fmt.Errorf("error inserting neighborhoods: %w", err)
+	}
+
+	if err := insertStreets(tx, dataset); err != nil {
+		return fmt.Errorf("error inserting streets: %w", err)
+	}
+
+	if err := insertAddresses(tx, dataset); err != nil {
+		return fmt.Errorf("error inserting addresses: %w", err)
+	}
+
+	if _, err := tx.Exec(`INSERT INTO streets_fts (streets_fts) VALUES ('rebuild')`); err != nil {
+		return fmt.Errorf("error building street search index: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return db.Close()
+}
+
+func insertLocalities(tx *sql.Tx, locations map[int]models.Location) error {
+	stmt, err := tx.Prepare(`INSERT INTO localities
+		(id, state, name, cep, situation, type, subordinate_id, abbreviation, ibge_code)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	// Subordinate localities point at other rows of the same table, so the
+	// references are filled in once every locality exists.
+	for _, l := range sorted(locations) {
+		_, err := stmt.Exec(l.ID, l.State, l.Name, optionalZipCode(l.ZipCode), int(l.Situation), string(l.Type),
+			nil, nullString(l.Abbreviation), nullString(l.IBGECode))
+		if err != nil {
+			return fmt.Errorf("locality %d: %w", l.ID, err)
+		}
+	}
+
+	update, err := tx.Prepare(`UPDATE localities SET subordinate_id = ? WHERE id = ?`)
+	if err != nil {
+		return err
+	}
+	defer update.Close()
+
+	for _, l := range sorted(locations) {
+		if _, ok := locations[l.SubordinateLocationID]; !ok {
+			continue
+		}
+		if _, err := update.Exec(l.SubordinateLocationID, l.ID); err != nil {
+			return fmt.Errorf("locality %d: %w", l.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func insertNeighborhoods(tx *sql.Tx, dataset *models.Dataset) error {
+	stmt, err := tx.Prepare(`INSERT INTO neighborhoods
+		(id, state, locality_id, name, abbreviation)
+		VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, n := range sorted(dataset.Neighborhoods) {
+		_, err := stmt.Exec(n.ID, n.State, reference(dataset.Locations, n.LocationID), n.Name, nullString(n.Abbreviation))
+		if err != nil {
+			return fmt.Errorf("neighborhood %d: %w", n.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func insertStreets(tx *sql.Tx, dataset *models.Dataset) error {
+	stmt, err := tx.Prepare(`INSERT INTO streets
+		(id, cep, state, locality_id, starting_neighborhood_id, ending_neighborhood_id,
+		 type, use_type, name, complement, abbreviation)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, s := range sorted(dataset.Streets) {
+		_, err := stmt.Exec(s.ID, zipCode(s.ZipCode), s.State,
+			reference(dataset.Locations, s.LocationID),
+			neighborhoodReference(dataset.Neighborhoods, s.StartingNeighborhood),
+			neighborhoodReference(dataset.Neighborhoods, s.EndingNeighborhood),
+			s.Type, s.UseType, s.Name, nullString(s.Complement), nullString(s.Abbreviation))
+		if err != nil {
+			return fmt.Errorf("street %d: %w", s.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func insertAddresses(tx *sql.Tx, dataset *models.Dataset) error {
+	stmt, err := tx.Prepare(`INSERT INTO addresses
+		(cep, street_id, street_type, street, neighborhood, city, state, city_ibge_code)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, a := range sorted(dataset.Addresses) {
+		var streetID any
+		if street, ok := dataset.Streets[a.ZipCode]; ok {
+			streetID = street.ID
+		}
+
+		_, err := stmt.Exec(zipCode(a.ZipCode), streetID, a.StreetType, a.Street, a.Neighborhood, a.City, a.State,
+			nullString(a.CityIBGECode))
+		if err != nil {
+			return fmt.Errorf("address %08d: %w", a.ZipCode, err)
+		}
+	}
+
+	return nil
+}
+
+func reference[T any](entities map[int]T, id int) any {
+	if _, ok := entities[id]; !ok {
+		return nil
+	}
+	return id
+}
+
+func neighborhoodReference(neighborhoods map[int]models.Neighborhood, n *models.Neighborhood) any {
+	if n == nil {
+		return nil
+	}
+	return reference(neighborhoods, n.ID)
+}
+
+func nullString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,74 @@
+package export_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/export"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSQLite(t *testing.T) {
+	dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "edne.db")
+	require.NoError(t, export.SQLite(path, dataset))
+
+	// Exporting twice replaces the previous database.
+	require.NoError(t, export.SQLite(path, dataset))
+
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	for table, expected := range map[string]int{
+		"localities":    len(dataset.Locations),
+		"neighborhoods": len(dataset.Neighborhoods),
+		"streets":       len(dataset.Streets),
+		"addresses":     446,
+	} {
+		var count int
+		require.NoError(t, db.QueryRow("SELECT count(*) FROM "+table).Scan(&count))
+		require.Equal(t, expected, count, table)
+	}
+
+	rows, err := db.Query("PRAGMA foreign_key_check")
+	require.NoError(t, err)
+	require.False(t, rows.Next(), "foreign key violations found")
+	require.NoError(t, rows.Close())
+
+	// 1005314@DF@1778@1128@@SCEN Trecho 2 Conjunto 4@@70800122@Trecho@N@SCEN Tr 2 Cj 4
+	var name, streetType, abbreviation string
+	var useType bool
+	require.NoError(t, db.QueryRow(`SELECT s.name, s.type, s.use_type, s.abbreviation
+		FROM streets s WHERE s.cep = '70800122'`).Scan(&name, &streetType, &useType, &abbreviation))
+	require.Equal(t, "SCEN Trecho 2 Conjunto 4", name)
+	require.Equal(t, "Trecho", streetType)
+	require.False(t, useType)
+	require.Equal(t, "SCEN Tr 2 Cj 4", abbreviation)
+
+	var cep string
+	require.NoError(t, db.QueryRow(`SELECT s.cep FROM streets_fts
+		JOIN streets s ON s.id = streets_fts.rowid
+		WHERE streets_fts MATCH 'name:getulio AND name:vargas'`).Scan(&cep))
+	require.Equal(t, "29127225", cep)
+
+	var city, state string
+	require.NoError(t, db.QueryRow(`SELECT city, state FROM addresses WHERE cep = '06415235'`).Scan(&city, &state))
+	require.Equal(t, "Mosteiro Bento", city)
+	require.Equal(t, "SP", state)
+}
+
+func TestExportSQLitePath(t *testing.T) {
+	dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), "")
+	require.NoError(t, err)
+
+	// '?' and '#' are part of the file name, not of the DSN.
+	path := filepath.Join(t.TempDir(), "edne?v=1#2.db")
+	require.NoError(t, export.SQLite(path, dataset))
+	require.FileExists(t, path)
+}
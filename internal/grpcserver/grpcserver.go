@@ -0,0 +1,210 @@
+package grpcserver
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/NSXBet/edne/internal/directory"
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/search"
+	"github.com/NSXBet/edne/pkg/ednepb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 1000
+	maxBatchSize       = 1000
+)
+
+// Server implements ednepb.LookupServiceServer. It answers UNAVAILABLE
+// until a directory is set.
+type Server struct {
+	ednepb.UnimplementedLookupServiceServer
+
+	directory atomic.Pointer[directory.Directory]
+}
+
+func New() *Server {
+	return &Server{}
+}
+
+// SetDirectory makes the server ready, serving lookups from d.
+func (s *Server) SetDirectory(d *directory.Directory) {
+	s.directory.Store(d)
+}
+
+func (s *Server) Lookup(_ context.Context, req *ednepb.LookupRequest) (*ednepb.LookupResponse, error) {
+	d, err := s.ready()
+	if err != nil {
+		return nil, err
+	}
+
+	zipCode, err := models.ParseZipCode(req.GetCep())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	address, ok := d.LookupCEP(zipCode)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "CEP %s not found", models.FormatZipCode(zipCode))
+	}
+
+	response := &ednepb.LookupResponse{Address: NewAddress(address)}
+
+	if street, ok := d.Street(zipCode); ok {
+		response.Street = NewStreet(street)
+
+		if location, ok := d.Locality(street.LocationID); ok {
+			response.Location = NewLocation(location)
+		}
+
+		if street.StartingNeighborhood != nil {
+			if neighborhood, ok := d.Neighborhood(street.StartingNeighborhood.ID); ok {
+				response.Neighborhood = NewNeighborhood(neighborhood)
+			}
+		}
+	}
+
+	return response, nil
+}
+
+func (s *Server) BatchLookup(_ context.Context, req *ednepb.BatchLookupRequest) (*ednepb.BatchLookupResponse, error) {
+	d, err := s.ready()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.GetCeps()) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d CEPs per batch", maxBatchSize)
+	}
+
+	results := make([]*ednepb.BatchLookupResult, 0, len(req.GetCeps()))
+	for _, cep := range req.GetCeps() {
+		result := &ednepb.BatchLookupResult{Cep: cep}
+
+		zipCode, err := models.ParseZipCode(cep)
+		if err != nil {
+			result.Error = err.Error()
+		} else if address, ok := d.LookupCEP(zipCode); ok {
+			result.Found = true
+			result.Address = NewAddress(address)
+		}
+
+		results = append(results, result)
+	}
+
+	return &ednepb.BatchLookupResponse{Results: results}, nil
+}
+
+func (s *Server) Search(req *ednepb.SearchRequest, stream grpc.ServerStreamingServer[ednepb.SearchResult]) error {
+	d, err := s.ready()
+	if err != nil {
+		return err
+	}
+
+	if req.GetQuery() == "" {
+		return status.Error(codes.InvalidArgument, "query is required")
+	}
+
+	limit := int(req.GetLimit())
+	switch {
+	case limit == 0:
+		limit = defaultSearchLimit
+	case limit < 0 || limit > maxSearchLimit:
+		return status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxSearchLimit)
+	}
+
+	results := d.Search(req.GetQuery(),
+		search.WithState(req.GetState()),
+		search.WithCity(req.GetCity()),
+		search.WithNeighborhood(req.GetNeighborhood()),
+		search.WithLimit(limit),
+	)
+
+	for _, result := range results {
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		if err := stream.Send(&ednepb.SearchResult{Address: NewAddress(result.Address), Score: result.Score}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) ready() (*directory.Directory, error) {
+	d := s.directory.Load()
+	if d == nil {
+		return nil, status.Error(codes.Unavailable, "dataset not loaded")
+	}
+	return d, nil
+}
+
+func NewAddress(a models.Address) *ednepb.Address {
+	return &ednepb.Address{
+		Cep:          models.FormatZipCode(a.ZipCode),
+		StreetType:   a.StreetType,
+		Street:       a.Street,
+		Neighborhood: a.Neighborhood,
+		City:         a.City,
+		State:        a.State,
+		CityIbgeCode: a.CityIBGECode,
+	}
+}
+
+func NewLocation(l models.Location) *ednepb.Location {
+	location := &ednepb.Location{
+		Id:                    int64(l.ID),
+		State:                 l.State,
+		Name:                  l.Name,
+		Situation:             ednepb.LocationSituation(l.Situation),
+		Type:                  locationTypes[l.Type],
+		SubordinateLocationId: int64(l.SubordinateLocationID),
+		Abbreviation:          l.Abbreviation,
+		IbgeCode:              l.IBGECode,
+	}
+	if l.ZipCode != 0 {
+		location.Cep = models.FormatZipCode(l.ZipCode)
+	}
+	return location
+}
+
+func NewNeighborhood(n models.Neighborhood) *ednepb.Neighborhood {
+	return &ednepb.Neighborhood{
+		Id:           int64(n.ID),
+		State:        n.State,
+		LocationId:   int64(n.LocationID),
+		Name:         n.Name,
+		Abbreviation: n.Abbreviation,
+	}
+}
+
+func NewStreet(s models.Street) *ednepb.Street {
+	street := &ednepb.Street{
+		Id:         int64(s.ID),
+		Cep:        models.FormatZipCode(s.ZipCode),
+		State:      s.State,
+		LocationId: int64(s.LocationID),
+		Name:       s.Name,
+		Complement: s.Complement,
+		Type:       s.Type,
+	}
+	if s.StartingNeighborhood != nil {
+		street.StartingNeighborhoodId = int64(s.StartingNeighborhood.ID)
+	}
+	if s.EndingNeighborhood != nil {
+		street.EndingNeighborhoodId = int64(s.EndingNeighborhood.ID)
+	}
+	return street
+}
+
+var locationTypes = map[models.LocationType]ednepb.LocationType{
+	models.LocationTypeDistrict: ednepb.LocationType_LOCATION_TYPE_DISTRICT,
+	models.LocationTypeCity:     ednepb.LocationType_LOCATION_TYPE_CITY,
+	models.LocationTypeVillage:  ednepb.LocationType_LOCATION_TYPE_VILLAGE,
+}
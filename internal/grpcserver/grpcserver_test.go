@@ -0,0 +1,117 @@
+package grpcserver_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/directory"
+	"github.com/NSXBet/edne/internal/grpcserver"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/pkg/ednepb"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newClient(t *testing.T, loaded bool) ednepb.LookupServiceClient {
+	t.Helper()
+
+	srv := grpcserver.New()
+	if loaded {
+		dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+		require.NoError(t, err)
+		srv.SetDirectory(directory.New(dataset))
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	ednepb.RegisterLookupServiceServer(s, srv)
+	go func() { _ = s.Serve(listener) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return ednepb.NewLookupServiceClient(conn)
+}
+
+func TestLookup(t *testing.T) {
+	client := newClient(t, true)
+	ctx := context.Background()
+
+	response, err := client.Lookup(ctx, &ednepb.LookupRequest{Cep: "06415-235"})
+	require.NoError(t, err)
+	require.Equal(t, "06415235", response.GetAddress().GetCep())
+	require.Equal(t, "São Bento", response.GetAddress().GetStreet())
+	require.Equal(t, "Mosteiro Bento", response.GetLocation().GetName())
+	require.Equal(t, "Cruz de São Bento", response.GetNeighborhood().GetName())
+	require.Equal(t, int64(1303826), response.GetStreet().GetId())
+
+	_, err = client.Lookup(ctx, &ednepb.LookupRequest{Cep: "99999999"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.Lookup(ctx, &ednepb.LookupRequest{Cep: "123"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestBatchLookup(t *testing.T) {
+	client := newClient(t, true)
+
+	response, err := client.BatchLookup(context.Background(), &ednepb.BatchLookupRequest{
+		Ceps: []string{"29127225", "99999999", "bad"},
+	})
+	require.NoError(t, err)
+
+	results := response.GetResults()
+	require.Len(t, results, 3)
+	require.True(t, results[0].GetFound())
+	require.Equal(t, "29127225", results[0].GetAddress().GetCep())
+	require.False(t, results[1].GetFound())
+	require.Empty(t, results[1].GetError())
+	require.False(t, results[2].GetFound())
+	require.NotEmpty(t, results[2].GetError())
+}
+
+func TestSearch(t *testing.T) {
+	client := newClient(t, true)
+
+	stream, err := client.Search(context.Background(), &ednepb.SearchRequest{Query: "getulio vargas", State: "ES"})
+	require.NoError(t, err)
+
+	var results []*ednepb.SearchResult
+	for {
+		result, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		results = append(results, result)
+	}
+	require.Len(t, results, 1)
+	require.Equal(t, "29127225", results[0].GetAddress().GetCep())
+
+	stream, err = client.Search(context.Background(), &ednepb.SearchRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUnavailable(t *testing.T) {
+	client := newClient(t, false)
+
+	_, err := client.Lookup(context.Background(), &ednepb.LookupRequest{Cep: "29127225"})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -24,6 +24,7 @@ type Location struct {
 	Situation             LocationSituation
 	Type                  LocationType
 	SubordinateLocationID int
+	Abbreviation          string
 	IBGECode              string
 }
 
@@ -37,11 +38,18 @@ type Street struct {
 	Name                 string
 	Complement           string
 	Type                 string
+	// UseType is LOG_STA_TLO: whether the street type is part of the
+	// official name.
+	UseType      bool
+	Abbreviation string
 }
 
 type Neighborhood struct {
-	ID   int
-	Name string
+	ID           int
+	State        string
+	LocationID   int
+	Name         string
+	Abbreviation string
 }
 
 func ZipCodeMap(addrs []Street) map[int]Street {
@@ -0,0 +1,11 @@
+package models
+
+// Country is a country as ECT names it in ECT_PAIS, keyed by its ISO code.
+type Country struct {
+	Code            string
+	AlternativeCode string
+	Name            string
+	EnglishName     string
+	FrenchName      string
+	Abbreviation    string
+}
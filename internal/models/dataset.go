@@ -0,0 +1,14 @@
+package models
+
+// Dataset holds every entity parsed from an eDNE release together with the
+// addresses joined from them.
+type Dataset struct {
+	Neighborhoods map[int]Neighborhood
+	Locations     map[int]Location
+	Streets       map[int]Street
+	NumberRanges  map[int]NumberRange
+	Addresses     map[int]Address
+	// Release describes the files the dataset was parsed from. It is nil
+	// for datasets built in code.
+	Release *Release
+}
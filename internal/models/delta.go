@@ -0,0 +1,40 @@
+package models
+
+// Operation is the change code carried by every record of a DELTA_ file.
+type Operation string
+
+const (
+	OperationInsert Operation = "INS"
+	OperationUpdate Operation = "UPD"
+	OperationDelete Operation = "DEL"
+)
+
+type LocationChange struct {
+	Operation       Operation
+	Location        Location
+	PreviousZipCode int
+}
+
+type NeighborhoodChange struct {
+	Operation    Operation
+	Neighborhood Neighborhood
+}
+
+type StreetChange struct {
+	Operation       Operation
+	Street          Street
+	PreviousZipCode int
+}
+
+type NumberRangeChange struct {
+	Operation   Operation
+	NumberRange NumberRange
+}
+
+// Delta is the ordered list of changes shipped in a Correios update.
+type Delta struct {
+	Locations     []LocationChange
+	Neighborhoods []NeighborhoodChange
+	Streets       []StreetChange
+	NumberRanges  []NumberRangeChange
+}
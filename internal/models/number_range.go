@@ -0,0 +1,55 @@
+package models
+
+// NumberSide tells which house numbers of a street section a CEP covers.
+type NumberSide string
+
+const (
+	NumberSideBoth  NumberSide = "A"
+	NumberSideEven  NumberSide = "P"
+	NumberSideOdd   NumberSide = "I"
+	NumberSideRight NumberSide = "D"
+	NumberSideLeft  NumberSide = "E"
+)
+
+// NumberRange is the stretch of house numbers of a street served by its CEP,
+// as listed in LOG_NUM_SEC for streets split across several CEPs.
+type NumberRange struct {
+	StreetID int
+	Start    int
+	End      int
+	Side     NumberSide
+	// Kilometres is set for highway sections, given in kilometres with a
+	// decimal comma. Start and End are then in metres.
+	Kilometres bool
+}
+
+// Contains reports whether number falls in the range and on its side.
+// Right and left sides cannot be told apart from the number alone. On a
+// kilometre range number is a kilometre, compared in metres with the range
+// while its own parity gives the side.
+func (r NumberRange) Contains(number int) bool {
+	position := number
+	if r.Kilometres {
+		position = number * 1000
+	}
+	if position < r.Start || position > r.End {
+		return false
+	}
+
+	switch r.Side {
+	case NumberSideEven:
+		return number%2 == 0
+	case NumberSideOdd:
+		return number%2 == 1
+	default:
+		return true
+	}
+}
+
+func NumberRangeMap(ranges []NumberRange) map[int]NumberRange {
+	m := make(map[int]NumberRange)
+	for _, r := range ranges {
+		m[r.StreetID] = r
+	}
+	return m
+}
@@ -0,0 +1,43 @@
+package models
+
+// LargeUser is a company or public body with a CEP of its own, as listed in
+// LOG_GRANDE_USUARIO. StreetID is zero when the street is not coded.
+type LargeUser struct {
+	ID             int
+	State          string
+	LocationID     int
+	NeighborhoodID int
+	StreetID       int
+	Name           string
+	Address        string
+	ZipCode        int
+	Abbreviation   string
+}
+
+// OperationalUnit is a Correios agency or distribution centre with a CEP of
+// its own, as listed in LOG_UNID_OPER.
+type OperationalUnit struct {
+	ID             int
+	State          string
+	LocationID     int
+	NeighborhoodID int
+	StreetID       int
+	Name           string
+	Address        string
+	ZipCode        int
+	// PostOfficeBoxes is UOP_IN_CP: whether the unit rents post office
+	// boxes, numbered as in LOG_FAIXA_UOP.
+	PostOfficeBoxes bool
+	Abbreviation    string
+}
+
+// CPC is a community post office box (Caixa Postal Comunitária) serving a
+// rural area, as listed in LOG_CPC.
+type CPC struct {
+	ID         int
+	State      string
+	LocationID int
+	Name       string
+	Address    string
+	ZipCode    int
+}
@@ -0,0 +1,49 @@
+package models
+
+// StateRange is the CEP range of a UF, as listed in LOG_FAIXA_UF.
+type StateRange struct {
+	State string
+	Start int
+	End   int
+}
+
+// LocationRangeType tells which part of a locality a CEP range covers.
+type LocationRangeType string
+
+const (
+	LocationRangeTypeTotal LocationRangeType = "T"
+	LocationRangeTypeUrban LocationRangeType = "C"
+)
+
+// LocationRange is a CEP range of a locality, as listed in
+// LOG_FAIXA_LOCALIDADE.
+type LocationRange struct {
+	LocationID int
+	Start      int
+	End        int
+	Type       LocationRangeType
+}
+
+// NeighborhoodRange is a CEP range of a neighborhood, as listed in
+// LOG_FAIXA_BAIRRO.
+type NeighborhoodRange struct {
+	NeighborhoodID int
+	Start          int
+	End            int
+}
+
+// CPCRange is the range of box numbers of a CPC, as listed in
+// LOG_FAIXA_CPC.
+type CPCRange struct {
+	CPCID int
+	Start int
+	End   int
+}
+
+// OperationalUnitRange is a range of post office boxes of an operational
+// unit, as listed in LOG_FAIXA_UOP.
+type OperationalUnitRange struct {
+	OperationalUnitID int
+	Start             int
+	End               int
+}
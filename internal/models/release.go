@@ -0,0 +1,66 @@
+package models
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Release describes where a dataset was loaded from, so a server can report
+// which CEP base it is serving.
+type Release struct {
+	Base Source
+	// Update is nil when no delta was applied.
+	Update   *Source
+	LoadedAt time.Time
+	Duration time.Duration
+}
+
+// Source is a directory or archive of eDNE files.
+type Source struct {
+	// Name is the base name of the archive or directory, such as
+	// "eDNE_Basico_25011.zip".
+	Name string
+	// Version is the release code in Name, the last run of digits in it,
+	// which for a delta is its sequence. Correios only numbers the archives,
+	// not the DELTA_ files nor their records, so a directory not named
+	// after its archive has no Version.
+	Version string
+	// Date is the newest modification time of the files.
+	Date  time.Time
+	Files []File
+}
+
+// File is an eDNE file of a source.
+type File struct {
+	Name     string
+	Size     int64
+	Rows     int
+	SHA256   string
+	Format   string
+	Encoding string
+}
+
+// Rows returns the number of records across the files of the source.
+func (s Source) Rows() int {
+	rows := 0
+	for _, f := range s.Files {
+		rows += f.Rows
+	}
+	return rows
+}
+
+var digits = regexp.MustCompile(`[0-9]+`)
+
+// NewSourceName returns the Name and Version of a Source read from path.
+func NewSourceName(path string) (name, version string) {
+	name = filepath.Base(path)
+
+	runs := digits.FindAllString(strings.TrimSuffix(name, filepath.Ext(name)), -1)
+	if len(runs) > 0 {
+		version = runs[len(runs)-1]
+	}
+
+	return name, version
+}
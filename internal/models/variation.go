@@ -0,0 +1,26 @@
+package models
+
+// LocationVariation is another name a locality is known by, as listed in
+// LOG_VAR_LOC.
+type LocationVariation struct {
+	LocationID int
+	Order      int
+	Name       string
+}
+
+// NeighborhoodVariation is another name a neighborhood is known by, as
+// listed in LOG_VAR_BAI.
+type NeighborhoodVariation struct {
+	NeighborhoodID int
+	Order          int
+	Name           string
+}
+
+// StreetVariation is another name a street is known by, as listed in
+// LOG_VAR_LOG.
+type StreetVariation struct {
+	StreetID int
+	Order    int
+	Type     string
+	Name     string
+}
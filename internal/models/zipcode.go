@@ -0,0 +1,42 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidZipCode = errors.New("invalid CEP")
+
+// ParseZipCode reads a CEP written as "29127225" or "29127-225".
+func ParseZipCode(s string) (int, error) {
+	digits := strings.TrimSpace(s)
+	if len(digits) == 9 && digits[5] == '-' {
+		digits = digits[:5] + digits[6:]
+	}
+
+	if len(digits) != 8 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidZipCode, s)
+	}
+
+	zipCode := 0
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidZipCode, s)
+		}
+		zipCode = zipCode*10 + int(r-'0')
+	}
+
+	return zipCode, nil
+}
+
+// FormatZipCode writes a CEP with its eight digits, as in "06415235".
+func FormatZipCode(zipCode int) string {
+	return fmt.Sprintf("%08d", zipCode)
+}
+
+// MaskZipCode writes a CEP in the "06415-235" form used on envelopes.
+func MaskZipCode(zipCode int) string {
+	s := FormatZipCode(zipCode)
+	return s[:5] + "-" + s[5:]
+}
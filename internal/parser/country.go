@@ -0,0 +1,19 @@
+package parser
+
+import (
+	"github.com/NSXBet/edne/internal/models"
+)
+
+// ParseCountries reads ECT_PAIS from dir.
+func ParseCountries(dir string) ([]models.Country, error) {
+	return parseRecords(nil, dir, countryLayout, func(r record) (models.Country, error) {
+		return models.Country{
+			Code:            r.text("PAI_SG"),
+			AlternativeCode: r.text("PAI_SG_ALTERNATIVA"),
+			Name:            r.text("PAI_NO_PORTUGUES"),
+			EnglishName:     r.text("PAI_NO_INGLES"),
+			FrenchName:      r.text("PAI_NO_FRANCES"),
+			Abbreviation:    r.text("PAI_ABREVIATURA"),
+		}, nil
+	})
+}
@@ -0,0 +1,25 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCountries(t *testing.T) {
+	countries, err := parser.ParseCountries(test.Fixture("base"))
+	require.NoError(t, err)
+	require.Len(t, countries, 7)
+
+	// AF@AFG@Afeganistão@Afghanistan@Afghanistan@
+	require.Equal(t, models.Country{
+		Code:            "AF",
+		AlternativeCode: "AFG",
+		Name:            "Afeganistão",
+		EnglishName:     "Afghanistan",
+		FrenchName:      "Afghanistan",
+	}, countries[0])
+}
@@ -0,0 +1,124 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/NSXBet/edne/internal/models"
+)
+
+type DeltaParser struct {
+	files fileLog
+}
+
+func NewDeltaParser() *DeltaParser {
+	return &DeltaParser{}
+}
+
+// Parse reads the DELTA_ files in update keeping the operation of each
+// record, in file order. Records without an operation code are treated as
+// updates, which is how the merging parsers apply them.
+func (p *DeltaParser) Parse(update string) (*models.Delta, error) {
+	delta := &models.Delta{}
+
+	var err error
+
+	delta.Locations, err = parseLocationChanges(p.files, update)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing localities: %w", err)
+	}
+
+	delta.Neighborhoods, err = parseNeighborhoodChanges(p.files, update)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing neighborhoods: %w", err)
+	}
+
+	delta.Streets, err = parseStreetChanges(p.files, update)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing streets: %w", err)
+	}
+
+	delta.NumberRanges, err = parseNumberRangeChanges(p.files, update)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing number ranges: %w", err)
+	}
+
+	return delta, nil
+}
+
+func parseLocationChanges(files fileLog, dir string) ([]models.LocationChange, error) {
+	var changes []models.LocationChange
+
+	err := readRecords(files, dir, deltaLocationLayout, func(r record) error {
+		location, err := parseLocationRecord(r)
+		if err != nil {
+			return err
+		}
+
+		changes = append(changes, models.LocationChange{
+			Operation:       r.operation(),
+			Location:        location,
+			PreviousZipCode: r.int("CEP_ANT"),
+		})
+		return nil
+	})
+
+	return changes, err
+}
+
+func parseNeighborhoodChanges(files fileLog, dir string) ([]models.NeighborhoodChange, error) {
+	var changes []models.NeighborhoodChange
+
+	err := readRecords(files, dir, deltaNeighborhoodLayout, func(r record) error {
+		neighborhood, err := parseNeighborhoodRecord(r)
+		if err != nil {
+			return err
+		}
+
+		changes = append(changes, models.NeighborhoodChange{
+			Operation:    r.operation(),
+			Neighborhood: neighborhood,
+		})
+		return nil
+	})
+
+	return changes, err
+}
+
+func parseStreetChanges(files fileLog, dir string) ([]models.StreetChange, error) {
+	var changes []models.StreetChange
+
+	err := readRecords(files, dir, deltaStreetLayout, func(r record) error {
+		street, err := parseStreetRecord(r)
+		if err != nil {
+			return err
+		}
+
+		changes = append(changes, models.StreetChange{
+			Operation:       r.operation(),
+			Street:          street,
+			PreviousZipCode: r.int("CEP_ANT"),
+		})
+		return nil
+	})
+
+	return changes, err
+}
+
+func parseNumberRangeChanges(files fileLog, dir string) ([]models.NumberRangeChange, error) {
+	var changes []models.NumberRangeChange
+
+	err := readRecords(files, dir, deltaNumberRangeLayout, func(r record) error {
+		numberRange, err := parseNumberRangeRecord(r)
+		if err != nil {
+			return err
+		}
+
+		changes = append(changes, models.NumberRangeChange{
+			Operation:   r.operation(),
+			NumberRange: numberRange,
+		})
+		return nil
+	})
+
+	return changes, err
+}
@@ -0,0 +1,63 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDelta(t *testing.T) {
+	update := test.Fixture("update")
+	require.NotEmpty(t, update)
+
+	parser := parser.NewDeltaParser()
+
+	delta, err := parser.Parse(update)
+	require.NoError(t, err)
+	require.Len(t, delta.Streets, 426)
+	require.Len(t, delta.Neighborhoods, 33)
+	require.Len(t, delta.Locations, 1)
+	require.Len(t, delta.NumberRanges, 103)
+
+	operations := map[models.Operation]int{}
+	for _, change := range delta.Streets {
+		operations[change.Operation]++
+	}
+	require.Equal(t, map[models.Operation]int{
+		models.OperationInsert: 281,
+		models.OperationUpdate: 101,
+		models.OperationDelete: 44,
+	}, operations)
+
+	// 683449@SP@9138@17811@@Elza Truella Machado@@08505630@Rua@S@R Elza T Machado@UPD@08505630
+	change := delta.Streets[31]
+	require.Equal(t, models.OperationUpdate, change.Operation)
+	require.Equal(t, 683449, change.Street.ID)
+	require.Equal(t, "Elza Truella Machado", change.Street.Name)
+	require.Equal(t, 8505630, change.PreviousZipCode)
+
+	// 948781@AC@16@55415@@da Alegria@@69908654@Travessa@S@Tv da Alegria@DEL@
+	change = delta.Streets[139]
+	require.Equal(t, models.OperationDelete, change.Operation)
+	require.Equal(t, 948781, change.Street.ID)
+	require.Equal(t, 0, change.PreviousZipCode)
+
+	// 43283@SP@9189@Vila Real Continuação@Vl Real Continuação@UPD
+	require.Equal(t, models.OperationUpdate, delta.Neighborhoods[0].Operation)
+	require.Equal(t, "Vila Real Continuação", delta.Neighborhoods[0].Neighborhood.Name)
+
+	// 9858@RJ@Rio de Janeiro@@1@M@@Rio de Janeiro@1200401 has no operation code.
+	require.Equal(t, models.OperationUpdate, delta.Locations[0].Operation)
+	require.Equal(t, 9858, delta.Locations[0].Location.ID)
+
+	deleted := 0
+	for _, change := range delta.NumberRanges {
+		if change.Operation == models.OperationDelete {
+			deleted++
+		}
+	}
+	require.Equal(t, 10, deleted)
+}
@@ -0,0 +1,260 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// kind is the type of a column, which tells the record reader how to check
+// and convert it.
+type kind int
+
+const (
+	// text is kept as is, trimmed.
+	text kind = iota
+	// number must hold an integer.
+	number
+	// optionalNumber holds an integer or is blank, read as zero.
+	optionalNumber
+	// sectionNumber is a house number, or a highway kilometre with a decimal
+	// comma ("90,000", "90,5"), read in metres.
+	sectionNumber
+	// operation is the INS, UPD or DEL code of a DELTA_ record.
+	operation
+)
+
+// column is a field of an eDNE file, named as in the Correios layout
+// documents. Its width is the field size, which the fixed-width ("tamanho
+// fixo") variant pads every value to instead of separating fields with '@'.
+type column struct {
+	name  string
+	width int
+	kind  kind
+}
+
+// layout declares the columns of an eDNE file once for every format it is
+// read in.
+type layout struct {
+	// prefix is the start of the file names, matched regardless of case.
+	prefix  string
+	columns []column
+	// required is how many leading columns every record must have. Older
+	// releases lack the trailing ones, which are read as blank.
+	required int
+}
+
+func newLayout(prefix string, columns ...column) layout {
+	return layout{prefix: prefix, columns: columns, required: len(columns)}
+}
+
+// optional marks the last n columns as missing from older releases.
+func (l layout) optional(n int) layout {
+	l.required = len(l.columns) - n
+	return l
+}
+
+// matches reports whether a file belongs to the layout.
+func (l layout) matches(name string) bool {
+	name = strings.ToUpper(name)
+	return strings.HasPrefix(name, l.prefix) && strings.HasSuffix(name, ".TXT")
+}
+
+// fixedWidth reports whether line reads as a record of the fixed-width
+// variant of the layout: as long as every column padded, bar the padding
+// of the last one, with digits in every number column.
+func (l layout) fixedWidth(line string) bool {
+	if len(l.columns) == 0 {
+		return false
+	}
+
+	width := 0
+	for _, c := range l.columns {
+		width += c.width
+	}
+
+	runes := []rune(line)
+	if len(runes) < width-l.columns[len(l.columns)-1].width || len(runes) > width {
+		return false
+	}
+
+	offset := 0
+	for _, c := range l.columns[:len(l.columns)-1] {
+		field := strings.TrimSpace(string(runes[offset : offset+c.width]))
+		if (c.kind == number || c.kind == optionalNumber) && strings.Trim(field, "0123456789") != "" {
+			return false
+		}
+		offset += c.width
+	}
+
+	return true
+}
+
+// index returns the position of the named column. Layouts are declared in
+// this package, so an unknown name is a programming error.
+func (l layout) index(name string) int {
+	for i, c := range l.columns {
+		if c.name == name {
+			return i
+		}
+	}
+	panic(fmt.Sprintf("parser: layout %s has no column %s", l.prefix, name))
+}
+
+// delta returns the layout of the DELTA_ file of l, which appends the
+// operation code and, for records keyed by CEP, the previous CEP. Both may
+// be missing, a record without operation being an update.
+func (l layout) delta(operationColumn string, previousZipCode bool) layout {
+	columns := append([]column{}, l.columns...)
+	columns = append(columns, column{operationColumn, 3, operation})
+	if previousZipCode {
+		columns = append(columns, column{"CEP_ANT", 8, optionalNumber})
+	}
+
+	return layout{prefix: "DELTA_" + l.prefix, columns: columns, required: l.required}
+}
+
+var (
+	locationLayout = newLayout("LOG_LOCALIDADE",
+		column{"LOC_NU", 8, number},
+		column{"UFE_SG", 2, text},
+		column{"LOC_NO", 72, text},
+		column{"CEP", 8, optionalNumber},
+		column{"LOC_IN_SIT", 1, number},
+		column{"LOC_IN_TIPO_LOC", 1, text},
+		column{"LOC_NU_SUB", 8, optionalNumber},
+		column{"LOC_NO_ABREV", 36, text},
+		column{"MUN_NU", 7, text},
+	)
+	neighborhoodLayout = newLayout("LOG_BAIRRO",
+		column{"BAI_NU", 8, number},
+		column{"UFE_SG", 2, text},
+		column{"LOC_NU", 8, number},
+		column{"BAI_NO", 72, text},
+		column{"BAI_NO_ABREV", 36, text},
+	).optional(1)
+	streetLayout = newLayout("LOG_LOGRADOURO",
+		column{"LOG_NU", 8, number},
+		column{"UFE_SG", 2, text},
+		column{"LOC_NU", 8, number},
+		column{"BAI_NU_INI", 8, number},
+		column{"BAI_NU_FIM", 8, optionalNumber},
+		column{"LOG_NO", 100, text},
+		column{"LOG_COMPLEMENTO", 100, text},
+		column{"CEP", 8, number},
+		column{"TLO_TX", 36, text},
+		column{"LOG_STA_TLO", 1, text},
+		column{"LOG_NO_ABREV", 36, text},
+	).optional(2)
+	numberRangeLayout = newLayout("LOG_NUM_SEC",
+		column{"LOG_NU", 8, number},
+		column{"SEC_NU_INI", 10, sectionNumber},
+		column{"SEC_NU_FIM", 10, sectionNumber},
+		column{"SEC_IN_LADO", 1, text},
+	)
+	largeUserLayout = newLayout("LOG_GRANDE_USUARIO",
+		column{"GRU_NU", 8, number},
+		column{"UFE_SG", 2, text},
+		column{"LOC_NU", 8, number},
+		column{"BAI_NU", 8, optionalNumber},
+		column{"LOG_NU", 8, optionalNumber},
+		column{"GRU_NO", 72, text},
+		column{"GRU_ENDERECO", 100, text},
+		column{"CEP", 8, number},
+		column{"GRU_NO_ABREV", 36, text},
+	)
+	operationalUnitLayout = newLayout("LOG_UNID_OPER",
+		column{"UOP_NU", 8, number},
+		column{"UFE_SG", 2, text},
+		column{"LOC_NU", 8, number},
+		column{"BAI_NU", 8, optionalNumber},
+		column{"LOG_NU", 8, optionalNumber},
+		column{"UOP_NO", 100, text},
+		column{"UOP_ENDERECO", 100, text},
+		column{"CEP", 8, number},
+		column{"UOP_IN_CP", 1, text},
+		column{"UOP_NO_ABREV", 36, text},
+	).optional(2)
+	cpcLayout = newLayout("LOG_CPC",
+		column{"CPC_NU", 8, number},
+		column{"UFE_SG", 2, text},
+		column{"LOC_NU", 8, number},
+		column{"CPC_NO", 72, text},
+		column{"CPC_ENDERECO", 100, text},
+		column{"CEP", 8, number},
+	)
+	stateRangeLayout = newLayout("LOG_FAIXA_UF",
+		column{"UFE_SG", 2, text},
+		column{"UFE_CEP_INI", 8, number},
+		column{"UFE_CEP_FIM", 8, number},
+	)
+	locationRangeLayout = newLayout("LOG_FAIXA_LOCALIDADE",
+		column{"LOC_NU", 8, number},
+		column{"LOC_CEP_INI", 8, number},
+		column{"LOC_CEP_FIM", 8, number},
+		column{"LOC_TIPO_FAIXA", 1, text},
+	)
+	neighborhoodRangeLayout = newLayout("LOG_FAIXA_BAIRRO",
+		column{"BAI_NU", 8, number},
+		column{"FCB_CEP_INI", 8, number},
+		column{"FCB_CEP_FIM", 8, number},
+	)
+	cpcRangeLayout = newLayout("LOG_FAIXA_CPC",
+		column{"CPC_NU", 8, number},
+		column{"CPC_INICIAL", 6, number},
+		column{"CPC_FINAL", 6, number},
+	)
+	operationalUnitRangeLayout = newLayout("LOG_FAIXA_UOP",
+		column{"UOP_NU", 8, number},
+		column{"FNC_INICIAL", 8, number},
+		column{"FNC_FINAL", 8, number},
+	)
+	locationVariationLayout = newLayout("LOG_VAR_LOC",
+		column{"LOC_NU", 8, number},
+		column{"VAL_NU", 8, number},
+		column{"VAL_TX", 72, text},
+	)
+	neighborhoodVariationLayout = newLayout("LOG_VAR_BAI",
+		column{"BAI_NU", 8, number},
+		column{"VDB_NU", 8, number},
+		column{"VDB_TX", 72, text},
+	)
+	streetVariationLayout = newLayout("LOG_VAR_LOG",
+		column{"LOG_NU", 8, number},
+		column{"VLO_NU", 8, number},
+		column{"TLO_TX", 36, text},
+		column{"VLO_TX", 150, text},
+	)
+	countryLayout = newLayout("ECT_PAIS",
+		column{"PAI_SG", 2, text},
+		column{"PAI_SG_ALTERNATIVA", 3, text},
+		column{"PAI_NO_PORTUGUES", 72, text},
+		column{"PAI_NO_INGLES", 72, text},
+		column{"PAI_NO_FRANCES", 72, text},
+		column{"PAI_ABREVIATURA", 36, text},
+	)
+
+	deltaLocationLayout     = locationLayout.delta("LOC_OPERACAO", true)
+	deltaNeighborhoodLayout = neighborhoodLayout.delta("BAI_OPERACAO", false)
+	deltaStreetLayout       = streetLayout.delta("LOG_OPERACAO", true)
+	deltaNumberRangeLayout  = numberRangeLayout.delta("SEC_OPERACAO", false)
+)
+
+// layouts are every layout read by this package.
+var layouts = []layout{
+	locationLayout, neighborhoodLayout, streetLayout, numberRangeLayout,
+	largeUserLayout, operationalUnitLayout, cpcLayout,
+	stateRangeLayout, locationRangeLayout, neighborhoodRangeLayout, cpcRangeLayout, operationalUnitRangeLayout,
+	locationVariationLayout, neighborhoodVariationLayout, streetVariationLayout, countryLayout,
+	deltaLocationLayout, deltaNeighborhoodLayout, deltaStreetLayout, deltaNumberRangeLayout,
+}
+
+// layoutFor returns the layout of a file, or the zero layout if no layout
+// matches its name.
+func layoutFor(name string) layout {
+	for _, l := range layouts {
+		if l.matches(name) {
+			return l
+		}
+	}
+	return layout{}
+}
@@ -1,126 +1,68 @@
 package parser
 
 import (
-	"encoding/csv"
 	"fmt"
-	"io"
-	"os"
-	"path"
-	"strconv"
-	"strings"
+	"maps"
 
 	"github.com/NSXBet/edne/internal/models"
-	"golang.org/x/text/encoding/charmap"
-	"golang.org/x/text/transform"
 )
 
-type LocationParser struct{}
+type LocationParser struct {
+	files fileLog
+}
 
 func NewLocationParser() *LocationParser {
 	return &LocationParser{}
 }
 
 func (p *LocationParser) Parse(base, update string) (map[int]models.Location, error) {
+	locations := make(map[int]models.Location)
+
 	// Read base file
-	baseLocations, err := p.parseFile(base)
-	if err != nil {
+	if err := p.parseFile(base, locations); err != nil {
 		return nil, fmt.Errorf("error parsing base file: %w", err)
 	}
 
 	// Read update file if it exists
 	if update != "" {
-		updateLocations, err := p.parseFile(update)
-		if err != nil {
+		if err := p.parseFile(update, locations); err != nil {
 			return nil, fmt.Errorf("error parsing update file: %w", err)
 		}
-
-		for id, location := range updateLocations {
-			baseLocations[id] = location
-		}
 	}
 
-	return baseLocations, nil
+	return locations, nil
 }
 
-func (p *LocationParser) parseFile(basePath string) (map[int]models.Location, error) {
-	entries, err := os.ReadDir(basePath)
+// parseFile adds the LOG_LOCALIDADE records in dir to locations and
+// applies the DELTA_LOG_LOCALIDADE ones, which may delete some.
+func (p *LocationParser) parseFile(dir string, locations map[int]models.Location) error {
+	records, err := parseRecords(p.files, dir, locationLayout, parseLocationRecord)
 	if err != nil {
-		return nil, fmt.Errorf("error reading directory %s: %w", basePath, err)
+		return err
 	}
 
-	var locations []models.Location
-
-	for _, entry := range entries {
-		if !strings.HasPrefix(strings.ToUpper(entry.Name()), "LOG_LOCALIDADE") &&
-			!strings.HasPrefix(strings.ToUpper(entry.Name()), "DELTA_LOG_LOCALIDADE") {
-			continue
-		}
-
-		filepath := path.Join(basePath, entry.Name())
-		file, err := os.Open(filepath)
-		if err != nil {
-			return nil, fmt.Errorf("error opening file %s: %w", filepath, err)
-		}
-		defer file.Close()
-
-		dec := transform.NewReader(file, charmap.Windows1252.NewDecoder())
+	maps.Copy(locations, models.LocationMap(records))
 
-		reader := csv.NewReader(dec)
-		reader.Comma = '@'
-		reader.FieldsPerRecord = -1 // Allow variable number of fields
-		reader.TrimLeadingSpace = true
-		reader.LazyQuotes = true
-
-		for {
-			record, err := reader.Read()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				return nil, fmt.Errorf("error reading file %s: %w", filepath, err)
-			}
-
-			id, err := strconv.Atoi(strings.TrimSpace(record[0]))
-			if err != nil {
-				return nil, fmt.Errorf("error parsing ID: %w", err)
-			}
-
-			zipCode := 0
-			if record[3] != "" {
-				zipCode, err = strconv.Atoi(strings.TrimSpace(record[3]))
-				if err != nil {
-					return nil, fmt.Errorf("error parsing zip code: %w", err)
-				}
-			}
-
-			situation, err := strconv.Atoi(strings.TrimSpace(record[4]))
-			if err != nil {
-				return nil, fmt.Errorf("error parsing situation: %w", err)
-			}
-
-			subordinateLocationID := 0
-			if record[6] != "" {
-				subordinateLocationID, err = strconv.Atoi(strings.TrimSpace(record[6]))
-				if err != nil {
-					return nil, fmt.Errorf("error parsing subordinate location ID: %w", err)
-				}
-			}
+	changes, err := parseLocationChanges(p.files, dir)
+	if err != nil {
+		return err
+	}
 
-			// Parse each record into a Neighborhood object
-			location := models.Location{
-				ID:                    id,
-				State:                 strings.TrimSpace(record[1]),
-				Name:                  strings.TrimSpace(record[2]),
-				ZipCode:               zipCode,
-				Situation:             models.LocationSituation(situation),
-				Type:                  models.LocationType(record[5]),
-				SubordinateLocationID: subordinateLocationID,
-				IBGECode:              record[8],
-			}
+	applyLocationChanges(changes, locations)
 
-			locations = append(locations, location)
-		}
-	}
+	return nil
+}
 
-	return models.LocationMap(locations), nil
+func parseLocationRecord(r record) (models.Location, error) {
+	return models.Location{
+		ID:                    r.int("LOC_NU"),
+		State:                 r.text("UFE_SG"),
+		Name:                  r.text("LOC_NO"),
+		ZipCode:               r.int("CEP"),
+		Situation:             models.LocationSituation(r.int("LOC_IN_SIT")),
+		Type:                  models.LocationType(r.text("LOC_IN_TIPO_LOC")),
+		SubordinateLocationID: r.int("LOC_NU_SUB"),
+		Abbreviation:          r.text("LOC_NO_ABREV"),
+		IBGECode:              r.text("MUN_NU"),
+	}, nil
 }
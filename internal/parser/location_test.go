@@ -30,6 +30,7 @@ func TestParseLocation(t *testing.T) {
 	require.Equal(t, 16, location.ID)
 	require.Equal(t, "AC", location.State)
 	require.Equal(t, "Rio Branco", location.Name)
+	require.Equal(t, "Rio Branco", location.Abbreviation)
 	require.Equal(t, 0, location.ZipCode)
 	require.Equal(t, "1200401", location.IBGECode)
 	require.Equal(t, models.LocationSituationCodifiedStreet, location.Situation)
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/NSXBet/edne/internal/models"
 )
@@ -13,36 +14,184 @@ func NewMasterParser() *MasterParser {
 }
 
 func (p *MasterParser) Parse(base, update string) (map[int]models.Address, error) {
+	dataset, err := p.ParseDataset(base, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return dataset.Addresses, nil
+}
+
+// ParseDataset parses the release in base, applying the delta in update
+// when given, and describes the files read in Dataset.Release.
+func (p *MasterParser) ParseDataset(base, update string) (*models.Dataset, error) {
+	start := time.Now()
+
+	// The files are described as they are parsed, so each is read once.
+	baseFiles := fileLog{}
+
 	neighborhoodParser := NewNeighborhoodParser()
+	neighborhoodParser.files = baseFiles
 
-	neighborhoods, err := neighborhoodParser.Parse(base, update)
+	neighborhoods, err := neighborhoodParser.Parse(base, "")
 	if err != nil {
 		return nil, fmt.Errorf("error parsing neighborhoods: %w", err)
 	}
 
 	locationParser := NewLocationParser()
-	locations, err := locationParser.Parse(base, update)
+	locationParser.files = baseFiles
+	locations, err := locationParser.Parse(base, "")
 	if err != nil {
 		return nil, fmt.Errorf("error parsing locations: %w", err)
 	}
 
 	streetParser := NewStreetParser()
-	streets, err := streetParser.Parse(base, update)
+	streetParser.files = baseFiles
+	streets, err := streetParser.Parse(base, "")
 	if err != nil {
 		return nil, fmt.Errorf("error parsing streets: %w", err)
 	}
 
-	addresses := map[int]models.Address{}
+	numberRangeParser := NewNumberRangeParser()
+	numberRangeParser.files = baseFiles
+	numberRanges, err := numberRangeParser.Parse(base, "")
+	if err != nil {
+		return nil, fmt.Errorf("error parsing number ranges: %w", err)
+	}
+
+	updateFiles := fileLog{}
+
+	if update != "" {
+		deltaParser := NewDeltaParser()
+		deltaParser.files = updateFiles
+
+		delta, err := deltaParser.Parse(update)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing delta: %w", err)
+		}
+
+		applyDelta(delta, locations, neighborhoods, streets, numberRanges)
+	}
+
+	// Round strips the monotonic reading, which snapshots would not keep.
+	release := &models.Release{LoadedAt: start.Round(0)}
+
+	release.Base, err = describeSource(base, baseFiles)
+	if err != nil {
+		return nil, fmt.Errorf("error describing base: %w", err)
+	}
+
+	if update != "" {
+		source, err := describeSource(update, updateFiles)
+		if err != nil {
+			return nil, fmt.Errorf("error describing update: %w", err)
+		}
+		release.Update = &source
+	}
+
+	dataset := &models.Dataset{
+		Neighborhoods: neighborhoods,
+		Locations:     locations,
+		Streets:       streets,
+		NumberRanges:  numberRanges,
+		Addresses:     JoinAddresses(streets, neighborhoods, locations),
+		Release:       release,
+	}
+
+	release.Duration = time.Since(start)
+
+	return dataset, nil
+}
+
+// applyDelta applies the operations of a delta in file order.
+func applyDelta(
+	delta *models.Delta,
+	locations map[int]models.Location,
+	neighborhoods map[int]models.Neighborhood,
+	streets map[int]models.Street,
+	numberRanges map[int]models.NumberRange,
+) {
+	applyLocationChanges(delta.Locations, locations)
+	applyNeighborhoodChanges(delta.Neighborhoods, neighborhoods)
+	applyStreetChanges(delta.Streets, streets)
+	applyNumberRangeChanges(delta.NumberRanges, numberRanges)
+}
+
+func applyLocationChanges(changes []models.LocationChange, locations map[int]models.Location) {
+	for _, change := range changes {
+		if change.Operation == models.OperationDelete {
+			delete(locations, change.Location.ID)
+			continue
+		}
+		locations[change.Location.ID] = change.Location
+	}
+}
+
+func applyNeighborhoodChanges(changes []models.NeighborhoodChange, neighborhoods map[int]models.Neighborhood) {
+	for _, change := range changes {
+		if change.Operation == models.OperationDelete {
+			delete(neighborhoods, change.Neighborhood.ID)
+			continue
+		}
+		neighborhoods[change.Neighborhood.ID] = change.Neighborhood
+	}
+}
 
+// applyStreetChanges applies street operations to streets keyed by CEP, so
+// a street moved to another CEP, or deleted, is first looked up by its ID;
+// Correios often deletes a street and inserts it again under a new ID with
+// the same CEP.
+func applyStreetChanges(changes []models.StreetChange, streets map[int]models.Street) {
+	zipCodes := make(map[int]int, len(streets))
 	for zipCode, street := range streets {
-		neighborhood, ok := neighborhoods[street.StartingNeighborhood.ID]
-		if !ok {
-			neighborhood = models.Neighborhood{}
+		zipCodes[street.ID] = zipCode
+	}
+
+	for _, change := range changes {
+		street := change.Street
+
+		if zipCode, ok := zipCodes[street.ID]; ok && streets[zipCode].ID == street.ID {
+			delete(streets, zipCode)
+			delete(zipCodes, street.ID)
+		}
+
+		if change.Operation == models.OperationDelete {
+			continue
+		}
+
+		streets[street.ZipCode] = street
+		zipCodes[street.ID] = street.ZipCode
+	}
+}
+
+func applyNumberRangeChanges(changes []models.NumberRangeChange, numberRanges map[int]models.NumberRange) {
+	for _, change := range changes {
+		if change.Operation == models.OperationDelete {
+			delete(numberRanges, change.NumberRange.StreetID)
+			continue
+		}
+		numberRanges[change.NumberRange.StreetID] = change.NumberRange
+	}
+}
+
+// JoinAddresses builds the address of every street CEP from its locality
+// and starting neighborhood.
+func JoinAddresses(
+	streets map[int]models.Street,
+	neighborhoods map[int]models.Neighborhood,
+	locations map[int]models.Location,
+) map[int]models.Address {
+	addresses := make(map[int]models.Address, len(streets))
+
+	for zipCode, street := range streets {
+		var neighborhood models.Neighborhood
+		if street.StartingNeighborhood != nil {
+			neighborhood = neighborhoods[street.StartingNeighborhood.ID]
 		}
 
 		location, ok := locations[street.LocationID]
 		if !ok {
-			location = models.Location{}
+			location = models.Location{State: street.State}
 		}
 
 		addresses[zipCode] = models.Address{
@@ -56,5 +205,5 @@ func (p *MasterParser) Parse(base, update string) (map[int]models.Address, error
 		}
 	}
 
-	return addresses, nil
+	return addresses
 }
@@ -18,7 +18,7 @@ func TestMasterParser(t *testing.T) {
 	addresses, err := parser.Parse(base, update)
 	require.NoError(t, err)
 	require.NotEmpty(t, addresses)
-	require.Len(t, addresses, 450)
+	require.Len(t, addresses, 446)
 
 	zipCode := 6415235
 	require.Contains(t, addresses, zipCode)
@@ -32,4 +32,38 @@ func TestMasterParser(t *testing.T) {
 	require.Equal(t, zipCode, addr.ZipCode)
 	require.Equal(t, "SP", addr.State)
 	require.Equal(t, "06415235", addr.CityIBGECode)
+
+	// Streets whose locality is missing from the release keep their own UF.
+	addr = addresses[29127225]
+	require.Equal(t, "Presidente Getúlio Vargas", addr.Street)
+	require.Equal(t, "", addr.City)
+	require.Equal(t, "ES", addr.State)
+}
+
+// Each parser applies the DELTA_ operations of update the way ParseDataset
+// does, deleted records included.
+func TestParsersMatchDataset(t *testing.T) {
+	base, update := test.Fixture("base"), test.Fixture("update")
+
+	dataset, err := parser.NewMasterParser().ParseDataset(base, update)
+	require.NoError(t, err)
+
+	locations, err := parser.NewLocationParser().Parse(base, update)
+	require.NoError(t, err)
+	require.Equal(t, dataset.Locations, locations)
+
+	neighborhoods, err := parser.NewNeighborhoodParser().Parse(base, update)
+	require.NoError(t, err)
+	require.Equal(t, dataset.Neighborhoods, neighborhoods)
+
+	streets, err := parser.NewStreetParser().Parse(base, update)
+	require.NoError(t, err)
+	require.Equal(t, dataset.Streets, streets)
+
+	numberRanges, err := parser.NewNumberRangeParser().Parse(base, update)
+	require.NoError(t, err)
+	require.Equal(t, dataset.NumberRanges, numberRanges)
+
+	// 1274113@1@99998@A@DEL
+	require.NotContains(t, numberRanges, 1274113)
 }
@@ -1,98 +1,64 @@
 package parser
 
 import (
-	"encoding/csv"
 	"fmt"
-	"io"
-	"os"
-	"path"
-	"strconv"
-	"strings"
+	"maps"
 
 	"github.com/NSXBet/edne/internal/models"
-	"golang.org/x/text/encoding/charmap"
-	"golang.org/x/text/transform"
 )
 
-type NeighborhoodParser struct{}
+type NeighborhoodParser struct {
+	files fileLog
+}
 
 func NewNeighborhoodParser() *NeighborhoodParser {
 	return &NeighborhoodParser{}
 }
 
 func (p *NeighborhoodParser) Parse(base, update string) (map[int]models.Neighborhood, error) {
+	neighborhoods := make(map[int]models.Neighborhood)
+
 	// Read base file
-	baseNeighborhoods, err := p.parseFile(base)
-	if err != nil {
+	if err := p.parseFile(base, neighborhoods); err != nil {
 		return nil, fmt.Errorf("error parsing base file: %w", err)
 	}
 
 	// Read update file if it exists
 	if update != "" {
-		updateNeighborhoods, err := p.parseFile(update)
-		if err != nil {
+		if err := p.parseFile(update, neighborhoods); err != nil {
 			return nil, fmt.Errorf("error parsing update file: %w", err)
 		}
-
-		for id, neighborhood := range updateNeighborhoods {
-			baseNeighborhoods[id] = neighborhood
-		}
 	}
 
-	return baseNeighborhoods, nil
+	return neighborhoods, nil
 }
 
-func (p *NeighborhoodParser) parseFile(basePath string) (map[int]models.Neighborhood, error) {
-	entries, err := os.ReadDir(basePath)
+// parseFile adds the LOG_BAIRRO records in dir to neighborhoods and
+// applies the DELTA_LOG_BAIRRO ones, which may delete some.
+func (p *NeighborhoodParser) parseFile(dir string, neighborhoods map[int]models.Neighborhood) error {
+	records, err := parseRecords(p.files, dir, neighborhoodLayout, parseNeighborhoodRecord)
 	if err != nil {
-		return nil, fmt.Errorf("error reading directory %s: %w", basePath, err)
+		return err
 	}
 
-	var neighborhoods []models.Neighborhood
-
-	for _, entry := range entries {
-		if !strings.HasPrefix(strings.ToUpper(entry.Name()), "LOG_BAIRRO") &&
-			!strings.HasPrefix(strings.ToUpper(entry.Name()), "DELTA_LOG_BAIRRO") {
-			continue
-		}
-
-		filepath := path.Join(basePath, entry.Name())
-		file, err := os.Open(filepath)
-		if err != nil {
-			return nil, fmt.Errorf("error opening file %s: %w", filepath, err)
-		}
-		defer file.Close()
-
-		dec := transform.NewReader(file, charmap.Windows1252.NewDecoder())
+	maps.Copy(neighborhoods, models.NeighborhoodMap(records))
 
-		reader := csv.NewReader(dec)
-		reader.Comma = '@'
-		reader.LazyQuotes = true
-		reader.FieldsPerRecord = -1 // Allow variable number of fields
-		reader.TrimLeadingSpace = true
-
-		for {
-			record, err := reader.Read()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				return nil, fmt.Errorf("error reading file %s: %w", filepath, err)
-			}
+	changes, err := parseNeighborhoodChanges(p.files, dir)
+	if err != nil {
+		return err
+	}
 
-			id, err := strconv.Atoi(strings.TrimSpace(record[0]))
-			if err != nil {
-				return nil, fmt.Errorf("error parsing ID: %w", err)
-			}
+	applyNeighborhoodChanges(changes, neighborhoods)
 
-			// Parse each record into a Neighborhood object
-			neighborhood := models.Neighborhood{
-				ID:   id,
-				Name: strings.TrimSpace(record[3]),
-			}
-			neighborhoods = append(neighborhoods, neighborhood)
-		}
-	}
+	return nil
+}
 
-	return models.NeighborhoodMap(neighborhoods), nil
+func parseNeighborhoodRecord(r record) (models.Neighborhood, error) {
+	return models.Neighborhood{
+		ID:           r.int("BAI_NU"),
+		State:        r.text("UFE_SG"),
+		LocationID:   r.int("LOC_NU"),
+		Name:         r.text("BAI_NO"),
+		Abbreviation: r.text("BAI_NO_ABREV"),
+	}, nil
 }
@@ -26,7 +26,10 @@ func TestParseNeighborhood(t *testing.T) {
 	neighborhood := neighborhoods[5268]
 	require.NotNil(t, neighborhood)
 	require.Equal(t, 5268, neighborhood.ID)
+	require.Equal(t, "MG", neighborhood.State)
+	require.Equal(t, 3689, neighborhood.LocationID)
 	require.Equal(t, "Santa Paula", neighborhood.Name)
+	require.Equal(t, "Sta Paula", neighborhood.Abbreviation)
 
 	// 75324@SP@9009@Área Industrial Senhor Antônio Gasparini@A Ind Sr Antônio Gasparini@UPD
 	require.Contains(t, neighborhoods, 75324)
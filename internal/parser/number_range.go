@@ -0,0 +1,66 @@
+package parser
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/NSXBet/edne/internal/models"
+)
+
+type NumberRangeParser struct {
+	files fileLog
+}
+
+func NewNumberRangeParser() *NumberRangeParser {
+	return &NumberRangeParser{}
+}
+
+func (p *NumberRangeParser) Parse(base, update string) (map[int]models.NumberRange, error) {
+	ranges := make(map[int]models.NumberRange)
+
+	// Read base file
+	if err := p.parseFile(base, ranges); err != nil {
+		return nil, fmt.Errorf("error parsing base file: %w", err)
+	}
+
+	// Read update file if it exists
+	if update != "" {
+		if err := p.parseFile(update, ranges); err != nil {
+			return nil, fmt.Errorf("error parsing update file: %w", err)
+		}
+	}
+
+	return ranges, nil
+}
+
+// parseFile adds the LOG_NUM_SEC records in dir to ranges and
+// applies the DELTA_LOG_NUM_SEC ones, which may delete some.
+func (p *NumberRangeParser) parseFile(dir string, ranges map[int]models.NumberRange) error {
+	records, err := parseRecords(p.files, dir, numberRangeLayout, parseNumberRangeRecord)
+	if err != nil {
+		return err
+	}
+
+	maps.Copy(ranges, models.NumberRangeMap(records))
+
+	changes, err := parseNumberRangeChanges(p.files, dir)
+	if err != nil {
+		return err
+	}
+
+	applyNumberRangeChanges(changes, ranges)
+
+	return nil
+}
+
+func parseNumberRangeRecord(r record) (models.NumberRange, error) {
+	return models.NumberRange{
+		StreetID: r.int("LOG_NU"),
+		Start:    r.int("SEC_NU_INI"),
+		End:      r.int("SEC_NU_FIM"),
+		Side:     models.NumberSide(strings.ToUpper(r.text("SEC_IN_LADO"))),
+		Kilometres: strings.Contains(r.text("SEC_NU_INI"), ",") ||
+			strings.Contains(r.text("SEC_NU_FIM"), ","),
+	}, nil
+}
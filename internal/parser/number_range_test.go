@@ -0,0 +1,52 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNumberRange(t *testing.T) {
+	base := test.Fixture("base")
+	require.NotEmpty(t, base)
+	update := test.Fixture("update")
+	require.NotEmpty(t, update)
+
+	parser := parser.NewNumberRangeParser()
+
+	ranges, err := parser.Parse(base, update)
+	require.NoError(t, err)
+	require.NotEmpty(t, ranges)
+
+	// 287614@1@750@A
+	require.Contains(t, ranges, 287614)
+	r := ranges[287614]
+	require.Equal(t, 287614, r.StreetID)
+	require.Equal(t, 1, r.Start)
+	require.Equal(t, 750, r.End)
+	require.Equal(t, models.NumberSideBoth, r.Side)
+	require.True(t, r.Contains(750))
+	require.False(t, r.Contains(751))
+
+	// 1303702@1@99999@A@INS
+	require.Contains(t, ranges, 1303702)
+	require.Equal(t, 99999, ranges[1303702].End)
+
+	// 953942@90,000@91,005@A@UPD
+	require.Equal(t, 90000, ranges[953942].Start)
+	require.Equal(t, 91005, ranges[953942].End)
+}
+
+func TestNumberRangeSides(t *testing.T) {
+	even := models.NumberRange{Start: 232, End: 790, Side: models.NumberSideEven}
+	require.True(t, even.Contains(500))
+	require.False(t, even.Contains(501))
+	require.False(t, even.Contains(230))
+
+	odd := models.NumberRange{Start: 1, End: 591, Side: models.NumberSideOdd}
+	require.True(t, odd.Contains(501))
+	require.False(t, odd.Contains(500))
+}
@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"github.com/NSXBet/edne/internal/models"
+)
+
+// ParseLargeUsers reads LOG_GRANDE_USUARIO from dir.
+func ParseLargeUsers(dir string) ([]models.LargeUser, error) {
+	return parseRecords(nil, dir, largeUserLayout, func(r record) (models.LargeUser, error) {
+		return models.LargeUser{
+			ID:             r.int("GRU_NU"),
+			State:          r.text("UFE_SG"),
+			LocationID:     r.int("LOC_NU"),
+			NeighborhoodID: r.int("BAI_NU"),
+			StreetID:       r.int("LOG_NU"),
+			Name:           r.text("GRU_NO"),
+			Address:        r.text("GRU_ENDERECO"),
+			ZipCode:        r.int("CEP"),
+			Abbreviation:   r.text("GRU_NO_ABREV"),
+		}, nil
+	})
+}
+
+// ParseOperationalUnits reads LOG_UNID_OPER from dir.
+func ParseOperationalUnits(dir string) ([]models.OperationalUnit, error) {
+	return parseRecords(nil, dir, operationalUnitLayout, func(r record) (models.OperationalUnit, error) {
+		return models.OperationalUnit{
+			ID:              r.int("UOP_NU"),
+			State:           r.text("UFE_SG"),
+			LocationID:      r.int("LOC_NU"),
+			NeighborhoodID:  r.int("BAI_NU"),
+			StreetID:        r.int("LOG_NU"),
+			Name:            r.text("UOP_NO"),
+			Address:         r.text("UOP_ENDERECO"),
+			ZipCode:         r.int("CEP"),
+			PostOfficeBoxes: r.flag("UOP_IN_CP"),
+			Abbreviation:    r.text("UOP_NO_ABREV"),
+		}, nil
+	})
+}
+
+// ParseCPCs reads LOG_CPC from dir.
+func ParseCPCs(dir string) ([]models.CPC, error) {
+	return parseRecords(nil, dir, cpcLayout, func(r record) (models.CPC, error) {
+		return models.CPC{
+			ID:         r.int("CPC_NU"),
+			State:      r.text("UFE_SG"),
+			LocationID: r.int("LOC_NU"),
+			Name:       r.text("CPC_NO"),
+			Address:    r.text("CPC_ENDERECO"),
+			ZipCode:    r.int("CEP"),
+		}, nil
+	})
+}
@@ -0,0 +1,63 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLargeUsers(t *testing.T) {
+	users, err := parser.ParseLargeUsers(test.Fixture("base"))
+	require.NoError(t, err)
+	require.Len(t, users, 12)
+
+	// 33085@AC@11@39332@@AC Manoel Urbano Clique e Retire@Rua Valério Caldas Magalhães, 92@69950959@AC M U C Retire
+	require.Equal(t, models.LargeUser{
+		ID:             33085,
+		State:          "AC",
+		LocationID:     11,
+		NeighborhoodID: 39332,
+		Name:           "AC Manoel Urbano Clique e Retire",
+		Address:        "Rua Valério Caldas Magalhães, 92",
+		ZipCode:        69950959,
+		Abbreviation:   "AC M U C Retire",
+	}, users[0])
+}
+
+func TestParseOperationalUnits(t *testing.T) {
+	units, err := parser.ParseOperationalUnits(test.Fixture("base"))
+	require.NoError(t, err)
+	require.Len(t, units, 12)
+
+	// The sample is a copy of LOG_GRANDE_USUARIO, whose nine fields read as
+	// a unit of an older release without UOP_NO_ABREV.
+	// 33085@AC@11@39332@@AC Manoel Urbano Clique e Retire@Rua Valério Caldas Magalhães, 92@69950959@AC M U C Retire
+	require.Equal(t, models.OperationalUnit{
+		ID:             33085,
+		State:          "AC",
+		LocationID:     11,
+		NeighborhoodID: 39332,
+		Name:           "AC Manoel Urbano Clique e Retire",
+		Address:        "Rua Valério Caldas Magalhães, 92",
+		ZipCode:        69950959,
+	}, units[0])
+}
+
+func TestParseCPCs(t *testing.T) {
+	cpcs, err := parser.ParseCPCs(test.Fixture("base"))
+	require.NoError(t, err)
+	require.Len(t, cpcs, 12)
+
+	// 1285@AL@158@Conjunto Mutirão@Quadra 1 nº 37 - Conj.Mutirão - Rio Largo@57100990
+	require.Equal(t, models.CPC{
+		ID:         1285,
+		State:      "AL",
+		LocationID: 158,
+		Name:       "Conjunto Mutirão",
+		Address:    "Quadra 1 nº 37 - Conj.Mutirão - Rio Largo",
+		ZipCode:    57100990,
+	}, cpcs[0])
+}
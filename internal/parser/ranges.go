@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"github.com/NSXBet/edne/internal/models"
+)
+
+// ParseStateRanges reads LOG_FAIXA_UF from dir.
+func ParseStateRanges(dir string) ([]models.StateRange, error) {
+	return parseRecords(nil, dir, stateRangeLayout, func(r record) (models.StateRange, error) {
+		return models.StateRange{
+			State: r.text("UFE_SG"),
+			Start: r.int("UFE_CEP_INI"),
+			End:   r.int("UFE_CEP_FIM"),
+		}, nil
+	})
+}
+
+// ParseLocationRanges reads LOG_FAIXA_LOCALIDADE from dir.
+func ParseLocationRanges(dir string) ([]models.LocationRange, error) {
+	return parseRecords(nil, dir, locationRangeLayout, func(r record) (models.LocationRange, error) {
+		return models.LocationRange{
+			LocationID: r.int("LOC_NU"),
+			Start:      r.int("LOC_CEP_INI"),
+			End:        r.int("LOC_CEP_FIM"),
+			Type:       models.LocationRangeType(r.text("LOC_TIPO_FAIXA")),
+		}, nil
+	})
+}
+
+// ParseNeighborhoodRanges reads LOG_FAIXA_BAIRRO from dir.
+func ParseNeighborhoodRanges(dir string) ([]models.NeighborhoodRange, error) {
+	return parseRecords(nil, dir, neighborhoodRangeLayout, func(r record) (models.NeighborhoodRange, error) {
+		return models.NeighborhoodRange{
+			NeighborhoodID: r.int("BAI_NU"),
+			Start:          r.int("FCB_CEP_INI"),
+			End:            r.int("FCB_CEP_FIM"),
+		}, nil
+	})
+}
+
+// ParseCPCRanges reads LOG_FAIXA_CPC from dir.
+func ParseCPCRanges(dir string) ([]models.CPCRange, error) {
+	return parseRecords(nil, dir, cpcRangeLayout, func(r record) (models.CPCRange, error) {
+		return models.CPCRange{
+			CPCID: r.int("CPC_NU"),
+			Start: r.int("CPC_INICIAL"),
+			End:   r.int("CPC_FINAL"),
+		}, nil
+	})
+}
+
+// ParseOperationalUnitRanges reads LOG_FAIXA_UOP from dir.
+func ParseOperationalUnitRanges(dir string) ([]models.OperationalUnitRange, error) {
+	return parseRecords(nil, dir, operationalUnitRangeLayout, func(r record) (models.OperationalUnitRange, error) {
+		return models.OperationalUnitRange{
+			OperationalUnitID: r.int("UOP_NU"),
+			Start:             r.int("FNC_INICIAL"),
+			End:               r.int("FNC_FINAL"),
+		}, nil
+	})
+}
@@ -0,0 +1,44 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRanges(t *testing.T) {
+	base := test.Fixture("base")
+
+	states, err := parser.ParseStateRanges(base)
+	require.NoError(t, err)
+	require.Len(t, states, 30)
+	require.Equal(t, models.StateRange{State: "AC", Start: 69900000, End: 69999999}, states[0])
+
+	localities, err := parser.ParseLocationRanges(base)
+	require.NoError(t, err)
+	require.Len(t, localities, 19)
+	require.Equal(t, models.LocationRange{
+		LocationID: 1089,
+		Start:      45310000,
+		End:        45314999,
+		Type:       models.LocationRangeTypeTotal,
+	}, localities[0])
+
+	neighborhoods, err := parser.ParseNeighborhoodRanges(base)
+	require.NoError(t, err)
+	require.Len(t, neighborhoods, 20)
+	require.Equal(t, models.NeighborhoodRange{NeighborhoodID: 20815, Start: 13610566, End: 13610568}, neighborhoods[0])
+
+	cpcs, err := parser.ParseCPCRanges(base)
+	require.NoError(t, err)
+	require.Len(t, cpcs, 18)
+	require.Equal(t, models.CPCRange{CPCID: 5371, Start: 1, End: 72}, cpcs[0])
+
+	units, err := parser.ParseOperationalUnitRanges(base)
+	require.NoError(t, err)
+	require.Len(t, units, 7)
+	require.Equal(t, models.OperationalUnitRange{OperationalUnitID: 1763, Start: 108601, End: 108750}, units[0])
+}
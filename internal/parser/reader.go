@@ -0,0 +1,357 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/NSXBet/edne/internal/archive"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/transform"
+)
+
+// Format is the layout variant an eDNE file is distributed in.
+type Format string
+
+const (
+	// FormatDelimited separates fields with a delimiter, '@' in Correios
+	// releases.
+	FormatDelimited Format = "delimited"
+	// FormatFixedWidth pads every field to the size of its column.
+	FormatFixedWidth Format = "fixed-width"
+)
+
+// Encoding is the character encoding of an eDNE file.
+type Encoding string
+
+const (
+	// EncodingWindows1252 is what Correios distributes.
+	EncodingWindows1252 Encoding = "windows-1252"
+	// EncodingUTF8 is found in re-encoded copies.
+	EncodingUTF8 Encoding = "utf-8"
+)
+
+// Detection is what was sniffed from the start of an eDNE file.
+type Detection struct {
+	File      string
+	Format    Format
+	Delimiter rune // Zero for fixed-width files
+	Encoding  Encoding
+	BOM       bool
+}
+
+// sniffSize is how much of a file is looked at to tell its format.
+const sniffSize = 64 * 1024
+
+var (
+	utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+	// delimiters are tried in order; '@' is the one Correios uses and the
+	// others come from files re-exported by spreadsheet tools.
+	delimiters = []rune{'@', '|', '\t', ';'}
+)
+
+// Detect sniffs every eDNE file in dir, in name order. File names are
+// matched regardless of case.
+func Detect(dir string) ([]Detection, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory %s: %w", dir, err)
+	}
+
+	var detections []Detection
+
+	for _, entry := range entries {
+		if entry.IsDir() || !archive.IsDataFile(entry.Name()) {
+			continue
+		}
+
+		detection, err := detectFile(path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		detections = append(detections, detection)
+	}
+
+	return detections, nil
+}
+
+// DetectFormat tells the format of the release in dir from its first
+// LOG_LOCALIDADE or DELTA_LOG_LOCALIDADE file, which both variants ship.
+func DetectFormat(dir string) (Format, error) {
+	detections, err := Detect(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for _, detection := range detections {
+		name := strings.ToUpper(path.Base(detection.File))
+		if strings.HasPrefix(name, "LOG_LOCALIDADE") || strings.HasPrefix(name, "DELTA_LOG_LOCALIDADE") {
+			return detection.Format, nil
+		}
+	}
+
+	return "", fmt.Errorf("error detecting format of %s: no LOG_LOCALIDADE file", dir)
+}
+
+func detectFile(filepath string) (Detection, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return Detection{}, fmt.Errorf("error opening file %s: %w", filepath, err)
+	}
+	defer file.Close()
+
+	br := bufio.NewReaderSize(file, sniffSize)
+	detection := sniff(br, layoutFor(path.Base(filepath)))
+	detection.File = filepath
+
+	// An ASCII start tells nothing about the encoding, so the rest of the
+	// file is decoded until an accent settles it.
+	if head, _ := br.Peek(sniffSize); !detection.BOM && isASCII(head) {
+		dec := newDecoder(false)
+		if _, err := io.Copy(io.Discard, transform.NewReader(br, dec)); err != nil {
+			return Detection{}, fmt.Errorf("error reading file %s: %w", filepath, err)
+		}
+		detection.Encoding = dec.encoding(detection.Encoding)
+	}
+
+	return detection, nil
+}
+
+// sniff peeks at the start of r. A UTF-8 BOM, or non-ASCII text that is
+// valid UTF-8, makes it UTF-8; Windows-1252 accents are almost never valid
+// UTF-8. Every delimited layout has several fields, so a first line without
+// any delimiter is fixed-width. So is one that reads as a fixed-width record
+// of l, even if its text holds a '|', a tab or a ';'.
+func sniff(r *bufio.Reader, l layout) Detection {
+	head, _ := r.Peek(sniffSize)
+
+	detection := Detection{Format: FormatFixedWidth, Encoding: EncodingWindows1252}
+
+	if bytes.HasPrefix(head, utf8BOM) {
+		detection.BOM = true
+		detection.Encoding = EncodingUTF8
+		head = head[len(utf8BOM):]
+	} else if !isASCII(head) && utf8.Valid(completeLines(head)) {
+		detection.Encoding = EncodingUTF8
+	}
+
+	line := head
+	if i := bytes.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	line = bytes.TrimRight(line, "\r")
+
+	if len(bytes.TrimSpace(line)) == 0 {
+		detection.Format, detection.Delimiter = FormatDelimited, '@'
+		return detection
+	}
+
+	text := string(line)
+	if detection.Encoding == EncodingWindows1252 {
+		text, _ = charmap.Windows1252.NewDecoder().String(text)
+	}
+
+	for _, delimiter := range delimiters {
+		if delimiter != '@' && l.fixedWidth(text) {
+			break
+		}
+		if bytes.ContainsRune(line, delimiter) {
+			detection.Format, detection.Delimiter = FormatDelimited, delimiter
+			break
+		}
+	}
+
+	return detection
+}
+
+// completeLines drops the last line of a peeked buffer, which may end in
+// the middle of a multi-byte character.
+func completeLines(b []byte) []byte {
+	if i := bytes.LastIndexByte(b, '\n'); i >= 0 {
+		return b[:i]
+	}
+	return b
+}
+
+func isASCII(b []byte) bool {
+	for _, c := range b {
+		if c >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// decoder reads UTF-8 until it meets a sequence that is not, and from there
+// on reads Windows-1252, so a UTF-8 file is told apart wherever its first
+// accent is.
+type decoder struct {
+	windows1252 transform.Transformer
+	// fallback is set once an invalid sequence is met, and utf8 once a valid
+	// multi-byte one is.
+	fallback bool
+	utf8     bool
+}
+
+func newDecoder(windows1252 bool) *decoder {
+	return &decoder{windows1252: charmap.Windows1252.NewDecoder(), fallback: windows1252}
+}
+
+func (d *decoder) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	if d.fallback {
+		return d.windows1252.Transform(dst, src, atEOF)
+	}
+
+	for nSrc < len(src) {
+		size := 1
+		if src[nSrc] >= utf8.RuneSelf {
+			if !atEOF && !utf8.FullRune(src[nSrc:]) {
+				return nDst, nSrc, transform.ErrShortSrc
+			}
+
+			var r rune
+			if r, size = utf8.DecodeRune(src[nSrc:]); r == utf8.RuneError && size == 1 {
+				d.fallback = true
+				n, m, err := d.windows1252.Transform(dst[nDst:], src[nSrc:], atEOF)
+				return nDst + n, nSrc + m, err
+			}
+			d.utf8 = true
+		}
+
+		if nDst+size > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], src[nSrc:nSrc+size])
+		nSrc += size
+	}
+
+	return nDst, nSrc, nil
+}
+
+func (d *decoder) Reset() {
+	d.fallback, d.utf8 = false, false
+	d.windows1252.Reset()
+}
+
+// encoding returns the encoding of what was decoded so far, or sniffed if
+// it was all ASCII.
+func (d *decoder) encoding(sniffed Encoding) Encoding {
+	switch {
+	case d.fallback:
+		return EncodingWindows1252
+	case d.utf8:
+		return EncodingUTF8
+	default:
+		return sniffed
+	}
+}
+
+// recordReader reads the records of an eDNE file in whichever format and
+// encoding it is in, returning the fields of both formats as strings.
+type recordReader struct {
+	detection Detection
+	layout    layout
+	decoder   *decoder
+
+	delimited *csv.Reader
+	lines     *bufio.Reader
+	line      int
+}
+
+func newRecordReader(r io.Reader, l layout) *recordReader {
+	br := bufio.NewReaderSize(r, sniffSize)
+	rr := &recordReader{detection: sniff(br, l), layout: l}
+
+	if rr.detection.BOM {
+		_, _ = br.Discard(len(utf8BOM))
+	}
+	// Windows-1252 is only certain once the start failed to read as UTF-8.
+	head, _ := br.Peek(sniffSize)
+	rr.decoder = newDecoder(rr.detection.Encoding == EncodingWindows1252 && !isASCII(head))
+	dec := transform.NewReader(br, rr.decoder)
+
+	if rr.detection.Format == FormatFixedWidth {
+		rr.lines = bufio.NewReader(dec)
+		return rr
+	}
+
+	rr.delimited = csv.NewReader(dec)
+	rr.delimited.Comma = rr.detection.Delimiter
+	rr.delimited.LazyQuotes = true
+	rr.delimited.FieldsPerRecord = -1 // Allow variable number of fields
+	// Leading blanks are padding, unless they are the delimiter.
+	rr.delimited.TrimLeadingSpace = !unicode.IsSpace(rr.detection.Delimiter)
+
+	return rr
+}
+
+// Read returns the next record, or io.EOF once there are no more.
+func (rr *recordReader) Read() ([]string, error) {
+	if rr.delimited != nil {
+		return rr.delimited.Read()
+	}
+
+	for {
+		line, err := rr.lines.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		if line == "" && err != nil {
+			return nil, io.EOF
+		}
+		rr.line++
+
+		line = strings.TrimRight(line, "\r\n")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		return rr.split(line), nil
+	}
+}
+
+// Encoding returns the encoding of the records read so far.
+func (rr *recordReader) Encoding() Encoding {
+	return rr.decoder.encoding(rr.detection.Encoding)
+}
+
+// Line returns the line of the last record read.
+func (rr *recordReader) Line() int {
+	if rr.delimited != nil {
+		line, _ := rr.delimited.FieldPos(0)
+		return line
+	}
+	return rr.line
+}
+
+// split cuts a fixed-width line into the columns of the layout. Trailing
+// padding is often stripped, so a short line leaves its last fields blank,
+// and the last column takes whatever follows it.
+func (rr *recordReader) split(line string) []string {
+	runes := []rune(line)
+	record := make([]string, len(rr.layout.columns))
+
+	offset := 0
+	for i, c := range rr.layout.columns {
+		end := min(offset+c.width, len(runes))
+		if i == len(rr.layout.columns)-1 {
+			end = len(runes)
+		}
+		if offset < end {
+			record[i] = strings.TrimSpace(string(runes[offset:end]))
+		}
+		offset = end
+	}
+
+	return record
+}
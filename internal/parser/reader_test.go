@@ -0,0 +1,218 @@
+package parser_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/charmap"
+)
+
+func TestDetectFormat(t *testing.T) {
+	for dir, want := range map[string]parser.Format{
+		"base":         parser.FormatDelimited,
+		"update":       parser.FormatDelimited,
+		"fixed/base":   parser.FormatFixedWidth,
+		"fixed/update": parser.FormatFixedWidth,
+	} {
+		format, err := parser.DetectFormat(test.Fixture(dir))
+		require.NoError(t, err, dir)
+		require.Equal(t, want, format, dir)
+	}
+
+	_, err := parser.DetectFormat(t.TempDir())
+	require.Error(t, err)
+}
+
+// The fixed/ fixtures hold the same records as base/ and update/ padded to
+// the column sizes of the fixed-width layout.
+func TestParseFixedWidth(t *testing.T) {
+	masterParser := parser.NewMasterParser()
+
+	delimited, err := masterParser.ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	fixed, err := masterParser.ParseDataset(test.Fixture("fixed/base"), test.Fixture("fixed/update"))
+	require.NoError(t, err)
+
+	// The files differ, so only the records are compared.
+	delimited.Release, fixed.Release = nil, nil
+	require.Equal(t, delimited, fixed)
+
+	delimitedDelta, err := parser.NewDeltaParser().Parse(test.Fixture("update"))
+	require.NoError(t, err)
+
+	fixedDelta, err := parser.NewDeltaParser().Parse(test.Fixture("fixed/update"))
+	require.NoError(t, err)
+	require.Equal(t, delimitedDelta, fixedDelta)
+
+	delimitedUsers, err := parser.ParseLargeUsers(test.Fixture("base"))
+	require.NoError(t, err)
+
+	fixedUsers, err := parser.ParseLargeUsers(test.Fixture("fixed/base"))
+	require.NoError(t, err)
+	require.Equal(t, delimitedUsers, fixedUsers)
+
+	delimitedVariations, err := parser.ParseStreetVariations(test.Fixture("base"))
+	require.NoError(t, err)
+
+	fixedVariations, err := parser.ParseStreetVariations(test.Fixture("fixed/base"))
+	require.NoError(t, err)
+	require.Equal(t, delimitedVariations, fixedVariations)
+}
+
+// reencode copies the eDNE files of a fixture to a temporary directory the
+// way our data lake stores them: UTF-8, lower-case names and, optionally, a
+// BOM and another delimiter.
+func reencode(t *testing.T, fixture string, bom bool, delimiter string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	entries, err := os.ReadDir(test.Fixture(fixture))
+	require.NoError(t, err)
+
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".TXT") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(test.Fixture(fixture), entry.Name()))
+		require.NoError(t, err)
+
+		text, err := charmap.Windows1252.NewDecoder().String(string(data))
+		require.NoError(t, err)
+
+		text = strings.ReplaceAll(text, "@", delimiter)
+		if bom {
+			text = "\uFEFF" + text
+		}
+
+		name := filepath.Join(dir, strings.ToLower(entry.Name()))
+		require.NoError(t, os.WriteFile(name, []byte(text), 0o644))
+	}
+
+	return dir
+}
+
+func TestParseReencoded(t *testing.T) {
+	masterParser := parser.NewMasterParser()
+
+	want, err := masterParser.ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+	want.Release = nil
+
+	for _, tt := range []struct {
+		name      string
+		bom       bool
+		delimiter string
+	}{
+		{"utf-8", false, "@"},
+		{"utf-8 with BOM", true, "@"},
+		{"pipes", true, "|"},
+		{"tabs", false, "\t"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			base := reencode(t, "base", tt.bom, tt.delimiter)
+			update := reencode(t, "update", tt.bom, tt.delimiter)
+
+			got, err := masterParser.ParseDataset(base, update)
+			require.NoError(t, err)
+			got.Release = nil
+			require.Equal(t, want, got)
+
+			detections, err := parser.Detect(base)
+			require.NoError(t, err)
+			require.NotEmpty(t, detections)
+			for _, detection := range detections {
+				require.Equal(t, parser.FormatDelimited, detection.Format, detection.File)
+				require.Equal(t, []rune(tt.delimiter)[0], detection.Delimiter, detection.File)
+				require.Equal(t, tt.bom, detection.BOM, detection.File)
+
+				// Files without accents read the same either way.
+				if strings.HasSuffix(detection.File, "log_bairro.txt") {
+					require.Equal(t, parser.EncodingUTF8, detection.Encoding)
+				}
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	detections, err := parser.Detect(test.Fixture("base"))
+	require.NoError(t, err)
+	require.Len(t, detections, 18)
+
+	require.Equal(t, parser.Detection{
+		File:      filepath.Join(test.Fixture("base"), "ECT_PAIS.TXT"),
+		Format:    parser.FormatDelimited,
+		Delimiter: '@',
+		Encoding:  parser.EncodingWindows1252,
+	}, detections[0])
+
+	detections, err = parser.Detect(test.Fixture("fixed/base"))
+	require.NoError(t, err)
+	for _, detection := range detections {
+		require.Equal(t, parser.FormatFixedWidth, detection.Format, detection.File)
+		require.Zero(t, detection.Delimiter, detection.File)
+	}
+}
+
+// The encoding is sniffed from the start of a file, which may hold no
+// accent at all.
+func TestParseLateAccent(t *testing.T) {
+	dir := t.TempDir()
+
+	var text strings.Builder
+	for id := 1; text.Len() < 128*1024; id++ {
+		fmt.Fprintf(&text, "%d@AC@16@Bairro %d@B %d\r\n", id, id, id)
+	}
+	text.WriteString("999999@AC@16@Plácido de Castro@P Castro\r\n")
+
+	name := filepath.Join(dir, "LOG_BAIRRO.TXT")
+	require.NoError(t, os.WriteFile(name, []byte(text.String()), 0o644))
+
+	neighborhoods, err := parser.NewNeighborhoodParser().Parse(dir, "")
+	require.NoError(t, err)
+	require.Equal(t, "Plácido de Castro", neighborhoods[999999].Name)
+
+	detections, err := parser.Detect(dir)
+	require.NoError(t, err)
+	require.Equal(t, parser.EncodingUTF8, detections[0].Encoding)
+
+	// Windows-1252 past the start reads the same.
+	data, err := charmap.Windows1252.NewEncoder().String(text.String())
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(name, []byte(data), 0o644))
+
+	neighborhoods, err = parser.NewNeighborhoodParser().Parse(dir, "")
+	require.NoError(t, err)
+	require.Equal(t, "Plácido de Castro", neighborhoods[999999].Name)
+
+	detections, err = parser.Detect(dir)
+	require.NoError(t, err)
+	require.Equal(t, parser.EncodingWindows1252, detections[0].Encoding)
+}
+
+// A fixed-width name may hold a character the re-exported files use as
+// delimiter.
+func TestDetectFixedWidthDelimiter(t *testing.T) {
+	dir := t.TempDir()
+
+	line := fmt.Sprintf("%-8d%-2s%-8d%-72s%-36s\r\n", 41, "AC", 16, "Vila | Nova", "V Nova")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "LOG_BAIRRO.TXT"), []byte(line), 0o644))
+
+	detections, err := parser.Detect(dir)
+	require.NoError(t, err)
+	require.Equal(t, parser.FormatFixedWidth, detections[0].Format)
+
+	neighborhoods, err := parser.NewNeighborhoodParser().Parse(dir, "")
+	require.NoError(t, err)
+	require.Equal(t, "Vila | Nova", neighborhoods[41].Name)
+	require.Equal(t, "V Nova", neighborhoods[41].Abbreviation)
+}
@@ -0,0 +1,188 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/NSXBet/edne/internal/models"
+)
+
+// record is a row of an eDNE file whose fields were trimmed and checked
+// against the kinds of the columns of its layout.
+type record struct {
+	layout  layout
+	fields  []string
+	numbers []int
+}
+
+// text returns the trimmed value of a column.
+func (r record) text(name string) string {
+	return r.fields[r.layout.index(name)]
+}
+
+// int returns the value of a numeric column, zero when optional and blank.
+func (r record) int(name string) int {
+	return r.numbers[r.layout.index(name)]
+}
+
+// flag reports whether a S/N column is set.
+func (r record) flag(name string) bool {
+	return strings.EqualFold(r.text(name), "S")
+}
+
+// operation returns the operation of a DELTA_ record. Records without a
+// known code are updates, which is how Correios applies them.
+func (r record) operation() models.Operation {
+	for i, c := range r.layout.columns {
+		if c.kind != operation {
+			continue
+		}
+
+		switch op := models.Operation(strings.ToUpper(r.fields[i])); op {
+		case models.OperationInsert, models.OperationDelete:
+			return op
+		}
+	}
+
+	return models.OperationUpdate
+}
+
+// newRecord trims the fields of a row, pads those older releases leave out
+// and converts the numeric ones.
+func newRecord(l layout, fields []string) (record, error) {
+	if len(fields) < l.required {
+		return record{}, fmt.Errorf("expected %d fields, got %d", l.required, len(fields))
+	}
+
+	r := record{
+		layout:  l,
+		fields:  make([]string, len(l.columns)),
+		numbers: make([]int, len(l.columns)),
+	}
+
+	for i, c := range l.columns {
+		if i < len(fields) {
+			r.fields[i] = strings.TrimSpace(fields[i])
+		}
+
+		value := r.fields[i]
+
+		var err error
+		switch {
+		case c.kind == number, c.kind == optionalNumber && value != "":
+			r.numbers[i], err = strconv.Atoi(value)
+		case c.kind == sectionNumber:
+			r.numbers[i], err = parseSectionNumber(value)
+		}
+		if err != nil {
+			return record{}, fmt.Errorf("error parsing %s: %q is not a number", c.name, value)
+		}
+	}
+
+	return r, nil
+}
+
+// parseSectionNumber reads a house number, or a kilometre with a decimal
+// comma in metres: "90,5" is 90500.
+func parseSectionNumber(value string) (int, error) {
+	kilometres, fraction, ok := strings.Cut(value, ",")
+	if !ok {
+		return strconv.Atoi(value)
+	}
+
+	km, err := strconv.Atoi(kilometres)
+	if err != nil {
+		return 0, err
+	}
+	if len(fraction) == 0 || len(fraction) > 3 || strings.Trim(fraction, "0123456789") != "" {
+		return 0, strconv.ErrSyntax
+	}
+	metres, _ := strconv.Atoi(fraction + strings.Repeat("0", 3-len(fraction)))
+
+	return km*1000 + metres, nil
+}
+
+// parseRecords converts every record of the files of a layout in dir, in
+// file order, describing the files read in files unless it is nil.
+func parseRecords[T any](files fileLog, dir string, l layout, parse func(r record) (T, error)) ([]T, error) {
+	var values []T
+
+	err := readRecords(files, dir, l, func(r record) error {
+		value, err := parse(r)
+		if err != nil {
+			return err
+		}
+
+		values = append(values, value)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// readRecords calls fn with every record of the files of a layout in dir,
+// in name and file order, describing the files read in files unless it is
+// nil.
+func readRecords(files fileLog, dir string, l layout, fn func(r record) error) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("error reading directory %s: %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !l.matches(entry.Name()) {
+			continue
+		}
+
+		if err := readFile(files, path.Join(dir, entry.Name()), l, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readFile calls fn with every record of a file. The file is hashed and
+// its records counted as they are read, so files describes it without
+// reading it again.
+func readFile(files fileLog, filepath string, l layout, fn func(r record) error) error {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return fmt.Errorf("error opening file %s: %w", filepath, err)
+	}
+	defer file.Close()
+
+	var src io.Reader = file
+	hash := sha256.New()
+	if files != nil {
+		src = io.TeeReader(file, hash)
+	}
+
+	reader := newRecordReader(src, l)
+
+	for rows := 0; ; rows++ {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return files.add(file, reader, rows, hash)
+		}
+		if err != nil {
+			return fmt.Errorf("error reading file %s: %w", filepath, err)
+		}
+
+		r, err := newRecord(l, fields)
+		if err == nil {
+			err = fn(r)
+		}
+		if err != nil {
+			return fmt.Errorf("error reading file %s: line %d: %w", filepath, reader.Line(), err)
+		}
+	}
+}
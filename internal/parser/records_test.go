@@ -0,0 +1,93 @@
+package parser_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+
+	return dir
+}
+
+func TestRecordTrimming(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"LOG_BAIRRO.TXT":        " 41 @ AC @16@ Placas \r\n42@AC@16@Centro@Ctr\r\n",
+		"LOG_LOGRADOURO_AC.TXT": "1@ AC@16@41@@ Lua Azul @@69909052@ Rua \r\n",
+		"LOG_LOCALIDADE.TXT":    "16@AC@Rio Branco@@1@ M @@Rio Branco@ 1200401 \r\n",
+	})
+
+	dataset, err := parser.NewMasterParser().ParseDataset(dir, "")
+	require.NoError(t, err)
+
+	require.Equal(t, models.Neighborhood{ID: 41, State: "AC", LocationID: 16, Name: "Placas"}, dataset.Neighborhoods[41])
+	require.Equal(t, "Lua Azul", dataset.Streets[69909052].Name)
+	require.Equal(t, "Rua", dataset.Streets[69909052].Type)
+	require.Equal(t, models.LocationTypeCity, dataset.Locations[16].Type)
+	require.Equal(t, "1200401", dataset.Locations[16].IBGECode)
+}
+
+func TestRecordErrors(t *testing.T) {
+	for name, tt := range map[string]struct {
+		files map[string]string
+		err   string
+	}{
+		"bad number": {
+			map[string]string{"LOG_BAIRRO.TXT": "41@AC@16@Placas@Placas\r\n42@AC@x@Centro@Ctr\r\n"},
+			`LOG_BAIRRO.TXT: line 2: error parsing LOC_NU: "x" is not a number`,
+		},
+		"blank number": {
+			map[string]string{"LOG_LOGRADOURO_AC.TXT": "1@AC@16@41@@Lua Azul@@@Rua\r\n"},
+			`LOG_LOGRADOURO_AC.TXT: line 1: error parsing CEP: "" is not a number`,
+		},
+		"bad kilometre": {
+			map[string]string{"LOG_NUM_SEC.TXT": "287611@1,2345@99999@A\r\n"},
+			`LOG_NUM_SEC.TXT: line 1: error parsing SEC_NU_INI: "1,2345" is not a number`,
+		},
+		"short record": {
+			map[string]string{"LOG_NUM_SEC.TXT": "287611@1506@99999\r\n"},
+			"LOG_NUM_SEC.TXT: line 1: expected 4 fields, got 3",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parser.NewMasterParser().ParseDataset(writeFiles(t, tt.files), "")
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestDeltaOperations(t *testing.T) {
+	update := writeFiles(t, map[string]string{
+		"DELTA_LOG_BAIRRO.TXT":  "41@AC@16@Placas@Placas@ins\r\n42@AC@16@Centro@Ctr@ DEL \r\n43@AC@16@Norte@Nte@UPD\r\n44@AC@16@Sul@Sul\r\n",
+		"DELTA_LOG_NUM_SEC.TXT": "287611@90,5@99,500@A@INS\r\n",
+	})
+
+	delta, err := parser.NewDeltaParser().Parse(update)
+	require.NoError(t, err)
+
+	var operations []models.Operation
+	for _, change := range delta.Neighborhoods {
+		operations = append(operations, change.Operation)
+	}
+	require.Equal(t, []models.Operation{
+		models.OperationInsert, models.OperationDelete, models.OperationUpdate, models.OperationUpdate,
+	}, operations)
+
+	require.Equal(t, models.NumberRangeChange{
+		Operation: models.OperationInsert,
+		NumberRange: models.NumberRange{
+			StreetID: 287611, Start: 90500, End: 99500, Side: models.NumberSideBoth, Kilometres: true,
+		},
+	}, delta.NumberRanges[0])
+}
@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/NSXBet/edne/internal/archive"
+	"github.com/NSXBet/edne/internal/models"
+)
+
+// fileLog describes the files read while parsing a release, by path. A nil
+// fileLog keeps nothing.
+type fileLog map[string]models.File
+
+// add describes a file read to its end through reader, with rows records
+// and its content hashed into hash.
+func (f fileLog) add(file *os.File, reader *recordReader, rows int, hash hash.Hash) error {
+	if f == nil {
+		return nil
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("error reading file %s: %w", file.Name(), err)
+	}
+
+	f[file.Name()] = models.File{
+		Name:     filepath.Base(file.Name()),
+		Size:     info.Size(),
+		Rows:     rows,
+		SHA256:   hex.EncodeToString(hash.Sum(nil)),
+		Format:   string(reader.detection.Format),
+		Encoding: string(reader.Encoding()),
+	}
+
+	return nil
+}
+
+// describeSource sums up the eDNE files in dir: their rows, sizes and
+// checksums, and the format and encoding they were read in. Files already
+// described in files by parsing are not read again; the others are read
+// once, without a layout, only to be counted.
+func describeSource(dir string, files fileLog) (models.Source, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return models.Source{}, fmt.Errorf("error reading directory %s: %w", dir, err)
+	}
+
+	source := models.Source{}
+	source.Name, source.Version = models.NewSourceName(dir)
+
+	for _, entry := range entries {
+		if entry.IsDir() || !archive.IsDataFile(entry.Name()) {
+			continue
+		}
+
+		name := path.Join(dir, entry.Name())
+
+		if _, ok := files[name]; !ok {
+			err := readFile(files, name, layout{}, func(record) error { return nil })
+			if err != nil {
+				return models.Source{}, err
+			}
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return models.Source{}, fmt.Errorf("error reading file %s: %w", name, err)
+		}
+
+		if info.ModTime().After(source.Date) {
+			source.Date = info.ModTime()
+		}
+
+		source.Files = append(source.Files, files[name])
+	}
+
+	return source, nil
+}
@@ -0,0 +1,52 @@
+package parser_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRelease(t *testing.T) {
+	dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	release := dataset.Release
+	require.NotNil(t, release)
+	require.False(t, release.LoadedAt.IsZero())
+	require.Positive(t, release.Duration)
+	require.Equal(t, "base", release.Base.Name)
+	require.False(t, release.Base.Date.IsZero())
+
+	// Files are hashed while they are parsed, or counted if the dataset
+	// leaves them out, and either way read to their end.
+	files := map[string]int{}
+	for _, file := range release.Base.Files {
+		data, err := os.ReadFile(filepath.Join(test.Fixture("base"), file.Name))
+		require.NoError(t, err)
+		sum := sha256.Sum256(data)
+		require.Equal(t, hex.EncodeToString(sum[:]), file.SHA256, file.Name)
+		require.Equal(t, "delimited", file.Format, file.Name)
+		files[file.Name] = file.Rows
+	}
+	require.Len(t, files, 18)
+	require.Equal(t, 22, files["LOG_BAIRRO.TXT"])
+	require.Equal(t, 304, release.Base.Rows())
+	require.Equal(t, 30, files["LOG_FAIXA_UF.TXT"])
+
+	require.NotNil(t, release.Update)
+	require.Equal(t, "update", release.Update.Name)
+	require.Equal(t, 719, release.Update.Rows())
+
+	dataset, err = parser.NewMasterParser().ParseDataset(test.Fixture("fixed/base"), "")
+	require.NoError(t, err)
+	require.Nil(t, dataset.Release.Update)
+	// The fixed-width copy leaves out LOG_UNID_OPER and its 12 rows.
+	require.Equal(t, 292, dataset.Release.Base.Rows())
+	require.Equal(t, "fixed-width", dataset.Release.Base.Files[0].Format)
+}
@@ -18,7 +18,8 @@ func TestParseStreet(t *testing.T) {
 	addresses, err := parser.Parse(base, update)
 	require.NoError(t, err)
 	require.NotEmpty(t, addresses)
-	require.Len(t, addresses, 450)
+	// Streets deleted by the delta are dropped, as in ParseDataset.
+	require.Len(t, addresses, 446)
 
 	require.Contains(t, addresses, 70800122)
 	addr := addresses[70800122]
@@ -39,6 +40,8 @@ func TestParseStreet(t *testing.T) {
 	require.Equal(t, "", addr.Complement)
 	require.Equal(t, 70800122, addr.ZipCode)
 	require.Equal(t, "Trecho", addr.Type)
+	require.False(t, addr.UseType)
+	require.Equal(t, "SCEN Tr 2 Cj 4", addr.Abbreviation)
 
 	// 1303878@SP@9052@17217@@Otávio Gouveia@@15810115@Rua@S@R Otávio Gouveia@INS@
 	require.Contains(t, addresses, 15810115)
@@ -52,4 +55,6 @@ func TestParseStreet(t *testing.T) {
 	require.Equal(t, "", addr.Complement)
 	require.Equal(t, 15810115, addr.ZipCode)
 	require.Equal(t, "Rua", addr.Type)
+	require.True(t, addr.UseType)
+	require.Equal(t, "R Otávio Gouveia", addr.Abbreviation)
 }
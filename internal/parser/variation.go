@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"github.com/NSXBet/edne/internal/models"
+)
+
+// ParseLocationVariations reads LOG_VAR_LOC from dir.
+func ParseLocationVariations(dir string) ([]models.LocationVariation, error) {
+	return parseRecords(nil, dir, locationVariationLayout, func(r record) (models.LocationVariation, error) {
+		return models.LocationVariation{
+			LocationID: r.int("LOC_NU"),
+			Order:      r.int("VAL_NU"),
+			Name:       r.text("VAL_TX"),
+		}, nil
+	})
+}
+
+// ParseNeighborhoodVariations reads LOG_VAR_BAI from dir.
+func ParseNeighborhoodVariations(dir string) ([]models.NeighborhoodVariation, error) {
+	return parseRecords(nil, dir, neighborhoodVariationLayout, func(r record) (models.NeighborhoodVariation, error) {
+		return models.NeighborhoodVariation{
+			NeighborhoodID: r.int("BAI_NU"),
+			Order:          r.int("VDB_NU"),
+			Name:           r.text("VDB_TX"),
+		}, nil
+	})
+}
+
+// ParseStreetVariations reads LOG_VAR_LOG from dir.
+func ParseStreetVariations(dir string) ([]models.StreetVariation, error) {
+	return parseRecords(nil, dir, streetVariationLayout, func(r record) (models.StreetVariation, error) {
+		return models.StreetVariation{
+			StreetID: r.int("LOG_NU"),
+			Order:    r.int("VLO_NU"),
+			Type:     r.text("TLO_TX"),
+			Name:     r.text("VLO_TX"),
+		}, nil
+	})
+}
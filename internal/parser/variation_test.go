@@ -0,0 +1,34 @@
+package parser_test
+
+import (
+	"testing"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVariations(t *testing.T) {
+	base := test.Fixture("base")
+
+	localities, err := parser.ParseLocationVariations(base)
+	require.NoError(t, err)
+	require.Len(t, localities, 18)
+	require.Equal(t, models.LocationVariation{LocationID: 8357, Order: 10, Name: "Balneário"}, localities[0])
+
+	neighborhoods, err := parser.ParseNeighborhoodVariations(base)
+	require.NoError(t, err)
+	require.Len(t, neighborhoods, 13)
+	require.Equal(t, models.NeighborhoodVariation{NeighborhoodID: 14818, Order: 4, Name: "Jd Geni Mercatelli"}, neighborhoods[0])
+
+	streets, err := parser.ParseStreetVariations(base)
+	require.NoError(t, err)
+	require.Len(t, streets, 14)
+	require.Equal(t, models.StreetVariation{
+		StreetID: 3538,
+		Order:    2,
+		Type:     "Avenida",
+		Name:     "Avenida Augusto Ferreira da Silva",
+	}, streets[0])
+}
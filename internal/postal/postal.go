@@ -0,0 +1,202 @@
+package postal
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/NSXBet/edne/internal/models"
+)
+
+// Abbreviations are the short forms eDNE gives for the names of an
+// address. Street is LOG_NO_ABREV, which abbreviates the street type along
+// with the name, as in "R S Bento".
+type Abbreviations struct {
+	Street       string
+	Neighborhood string
+	City         string
+}
+
+// NewAbbreviations takes the abbreviated names of a street, its starting
+// neighborhood and its locality.
+func NewAbbreviations(street models.Street, neighborhood models.Neighborhood, location models.Location) Abbreviations {
+	return Abbreviations{
+		Street:       street.Abbreviation,
+		Neighborhood: neighborhood.Abbreviation,
+		City:         location.Abbreviation,
+	}
+}
+
+type FormatOption func(opts *FormatOptions)
+
+type FormatOptions struct {
+	Number        string
+	Complement    string
+	Abbreviations Abbreviations
+	Abbreviate    bool
+	MaxLineLength int
+}
+
+// WithNumber writes the house number after the street name.
+func WithNumber(number string) FormatOption {
+	return func(opts *FormatOptions) {
+		opts.Number = number
+	}
+}
+
+// WithComplement writes a complement, such as "Apto 45", after the number.
+func WithComplement(complement string) FormatOption {
+	return func(opts *FormatOptions) {
+		opts.Complement = complement
+	}
+}
+
+// WithAbbreviations gives the short forms to use when abbreviating. Names
+// without one are written in full.
+func WithAbbreviations(abbreviations Abbreviations) FormatOption {
+	return func(opts *FormatOptions) {
+		opts.Abbreviations = abbreviations
+	}
+}
+
+// WithAbbreviate writes every name in its short form.
+func WithAbbreviate() FormatOption {
+	return func(opts *FormatOptions) {
+		opts.Abbreviate = true
+	}
+}
+
+// WithMaxLineLength limits lines to length characters, as label printers
+// require. A line that does not fit is written with its short form, then
+// with the complement on a line of its own and, if it still does not fit,
+// wrapped between words.
+func WithMaxLineLength(length int) FormatOption {
+	return func(opts *FormatOptions) {
+		opts.MaxLineLength = length
+	}
+}
+
+// Lines returns the address as written on envelopes and labels:
+//
+//	Rua São Bento, 123 - Apto 45
+//	Cruz de São Bento
+//	Mosteiro Bento - SP
+//	06415-235
+//
+// Lines with nothing to show, such as the neighborhood of a locality CEP,
+// are left out.
+func Lines(address models.Address, opts ...FormatOption) []string {
+	options := &FormatOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	abbreviations := options.Abbreviations
+
+	street := strings.TrimSpace(address.StreetType + " " + address.Street)
+	lines := options.streetLines(street, abbreviations.Street)
+	lines = append(lines,
+		options.line(address.Neighborhood, abbreviations.Neighborhood),
+		options.line(cityLine(address.City, address.State), cityLine(abbreviations.City, address.State)),
+		models.MaskZipCode(address.ZipCode),
+	)
+
+	var formatted []string
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		formatted = append(formatted, wrap(line, options.MaxLineLength)...)
+	}
+
+	return formatted
+}
+
+// Format returns Lines joined by newlines.
+func Format(address models.Address, opts ...FormatOption) string {
+	return strings.Join(Lines(address, opts...), "\n")
+}
+
+// line picks between the full and the short form of a line.
+func (o *FormatOptions) line(full, short string) string {
+	if short == "" {
+		return full
+	}
+	if o.Abbreviate {
+		return short
+	}
+	if o.MaxLineLength > 0 && utf8.RuneCountInString(full) > o.MaxLineLength {
+		return short
+	}
+	return full
+}
+
+// streetLines writes the street with its number and complement, moving
+// the complement to a line of its own when they do not fit together.
+func (o *FormatOptions) streetLines(full, short string) []string {
+	if full == "" {
+		return nil
+	}
+
+	if o.Number != "" {
+		full += ", " + o.Number
+		if short != "" {
+			short += ", " + o.Number
+		}
+	}
+
+	name := o.line(full, short)
+	if o.Complement == "" {
+		return []string{name}
+	}
+
+	line := name + " - " + o.Complement
+	if o.MaxLineLength > 0 && utf8.RuneCountInString(line) > o.MaxLineLength {
+		return []string{name, o.Complement}
+	}
+	return []string{line}
+}
+
+func cityLine(city, state string) string {
+	switch {
+	case city == "":
+		return ""
+	case state == "":
+		return city
+	default:
+		return city + " - " + state
+	}
+}
+
+// wrap breaks line between words so that no part is longer than length,
+// splitting words that are longer on their own.
+func wrap(line string, length int) []string {
+	if length <= 0 || utf8.RuneCountInString(line) <= length {
+		return []string{line}
+	}
+
+	var lines []string
+	var current []rune
+	for _, word := range strings.Fields(line) {
+		runes := []rune(word)
+
+		if len(current) > 0 && len(current)+1+len(runes) > length {
+			lines = append(lines, string(current))
+			current = nil
+		}
+		if len(current) > 0 {
+			current = append(current, ' ')
+		}
+
+		for len(current)+len(runes) > length {
+			n := length - len(current)
+			lines = append(lines, string(append(current, runes[:n]...)))
+			current, runes = nil, runes[n:]
+		}
+		current = append(current, runes...)
+	}
+	if len(current) > 0 {
+		lines = append(lines, string(current))
+	}
+
+	return lines
+}
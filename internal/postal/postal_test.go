@@ -0,0 +1,82 @@
+package postal_test
+
+import (
+	"testing"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/postal"
+	"github.com/stretchr/testify/require"
+)
+
+var address = models.Address{
+	StreetType:   "Avenida",
+	Street:       "Presidente Getúlio Vargas",
+	Neighborhood: "Jardim da Penha",
+	City:         "Vitória",
+	State:        "ES",
+	ZipCode:      29127225,
+}
+
+var abbreviations = postal.Abbreviations{
+	Street:       "Av Pres Getúlio Vargas",
+	Neighborhood: "Jd Penha",
+	City:         "Vitória",
+}
+
+func TestLines(t *testing.T) {
+	require.Equal(t, []string{
+		"Avenida Presidente Getúlio Vargas, 1200 - Sala 3",
+		"Jardim da Penha",
+		"Vitória - ES",
+		"29127-225",
+	}, postal.Lines(address, postal.WithNumber("1200"), postal.WithComplement("Sala 3")))
+
+	require.Equal(t,
+		"Vitória - ES\n00041-000",
+		postal.Format(models.Address{City: "Vitória", State: "ES", ZipCode: 41000}))
+}
+
+func TestLinesAbbreviated(t *testing.T) {
+	require.Equal(t, []string{
+		"Av Pres Getúlio Vargas, 1200",
+		"Jd Penha",
+		"Vitória - ES",
+		"29127-225",
+	}, postal.Lines(address, postal.WithNumber("1200"), postal.WithAbbreviations(abbreviations), postal.WithAbbreviate()))
+
+	// Without WithAbbreviate the short forms are only used when needed.
+	require.Equal(t,
+		"Avenida Presidente Getúlio Vargas",
+		postal.Lines(address, postal.WithAbbreviations(abbreviations))[0])
+}
+
+func TestLinesMaxLength(t *testing.T) {
+	lines := postal.Lines(address,
+		postal.WithNumber("1200"),
+		postal.WithComplement("Bloco B Sala 301"),
+		postal.WithAbbreviations(abbreviations),
+		postal.WithMaxLineLength(30),
+	)
+	require.Equal(t, []string{
+		"Av Pres Getúlio Vargas, 1200",
+		"Bloco B Sala 301",
+		"Jardim da Penha",
+		"Vitória - ES",
+		"29127-225",
+	}, lines)
+
+	require.Equal(t, []string{
+		"Avenida",
+		"Presidente",
+		"Getúlio",
+		"Vargas",
+		"Jardim da",
+		"Penha",
+		"Vitória -",
+		"ES",
+		"29127-225",
+	}, postal.Lines(address, postal.WithMaxLineLength(10)))
+
+	require.Equal(t, []string{"Avenid", "a", "Presid", "ente"}, postal.Lines(
+		models.Address{StreetType: "Avenida", Street: "Presidente"}, postal.WithMaxLineLength(6))[:4])
+}
@@ -0,0 +1,241 @@
+package reload
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/NSXBet/edne/internal/archive"
+	"github.com/NSXBet/edne/internal/directory"
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+)
+
+const defaultPollInterval = 30 * time.Second
+
+// Loader parses a complete dataset, such as a base and its delta.
+type Loader func() (*models.Dataset, error)
+
+// FromDirectories loads the release in base and update with MasterParser.
+func FromDirectories(base, update string) Loader {
+	return func() (*models.Dataset, error) {
+		return parser.NewMasterParser().ParseDataset(base, update)
+	}
+}
+
+// FromArchives loads the release in base and update, each a directory or a
+// Correios zip, naming the release sources after the paths given rather than
+// the directories they were extracted to.
+func FromArchives(base, update string) Loader {
+	return func() (*models.Dataset, error) {
+		baseDir, closeBase, err := archive.Open(base)
+		if err != nil {
+			return nil, err
+		}
+		defer closeBase()
+
+		var updateDir string
+		if update != "" {
+			dir, closeUpdate, err := archive.Open(update)
+			if err != nil {
+				return nil, err
+			}
+			defer closeUpdate()
+
+			updateDir = dir
+		}
+
+		dataset, err := parser.NewMasterParser().ParseDataset(baseDir, updateDir)
+		if err != nil {
+			return nil, err
+		}
+
+		dataset.Release.Base.Name, dataset.Release.Base.Version = models.NewSourceName(base)
+		if dataset.Release.Update != nil {
+			dataset.Release.Update.Name, dataset.Release.Update.Version = models.NewSourceName(update)
+		}
+
+		return dataset, nil
+	}
+}
+
+// Event reports the outcome of a reload. On failure Err is set and the
+// previous directory keeps being served.
+type Event struct {
+	Previous *directory.Directory
+	Current  *directory.Directory
+	// Added, Removed and Modified hold the CEPs whose address changed,
+	// in ascending order.
+	Added    []int
+	Removed  []int
+	Modified []int
+	Duration time.Duration
+	Err      error
+}
+
+type HolderOption func(opts *HolderOptions)
+
+type HolderOptions struct {
+	OnReload     func(Event)
+	PollInterval time.Duration
+}
+
+// WithOnReload calls hook after every reload attempt, successful or not.
+// Hooks run on the reloading goroutine, so a slow hook delays the next one.
+func WithOnReload(hook func(Event)) HolderOption {
+	return func(opts *HolderOptions) {
+		opts.OnReload = hook
+	}
+}
+
+// WithPollInterval sets how often Watch checks its paths for changes.
+func WithPollInterval(interval time.Duration) HolderOption {
+	return func(opts *HolderOptions) {
+		opts.PollInterval = interval
+	}
+}
+
+// Holder serves an immutable directory and swaps in a newly loaded one
+// atomically. Readers never wait on a reload; they keep the directory they
+// got until they ask for it again.
+type Holder struct {
+	loader  Loader
+	options *HolderOptions
+
+	current atomic.Pointer[directory.Directory]
+	// reloading serializes reloads, never readers.
+	reloading sync.Mutex
+}
+
+func New(loader Loader, opts ...HolderOption) *Holder {
+	options := &HolderOptions{PollInterval: defaultPollInterval}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return &Holder{loader: loader, options: options}
+}
+
+// Directory returns the directory being served, or nil before the first
+// successful load.
+func (h *Holder) Directory() *directory.Directory {
+	return h.current.Load()
+}
+
+// Reload loads a new dataset and swaps it in. The previous directory is
+// kept when loading fails.
+func (h *Holder) Reload() error {
+	h.reloading.Lock()
+	defer h.reloading.Unlock()
+
+	start := time.Now()
+	previous := h.current.Load()
+
+	event := Event{Previous: previous, Current: previous}
+
+	dataset, err := h.loader()
+	if err != nil {
+		event.Err = fmt.Errorf("error loading dataset: %w", err)
+		event.Duration = time.Since(start)
+		h.notify(event)
+		return event.Err
+	}
+
+	current := directory.New(dataset)
+	h.current.Store(current)
+
+	event.Current = current
+	event.Added, event.Removed, event.Modified = changes(previous, current)
+	event.Duration = time.Since(start)
+	h.notify(event)
+
+	return nil
+}
+
+// Watch reloads whenever the files under paths change, until ctx is done.
+// A change is picked up once two polls in a row see the same files, so a
+// release still being copied is not loaded halfway.
+func (h *Holder) Watch(ctx context.Context, paths ...string) error {
+	last, err := fingerprint(paths)
+	if err != nil {
+		return err
+	}
+
+	pending := last
+
+	interval := h.options.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		current, err := fingerprint(paths)
+		if err != nil {
+			h.notify(Event{Previous: h.Directory(), Current: h.Directory(), Err: err})
+			continue
+		}
+
+		switch {
+		case current == last:
+			pending = last
+		case current != pending:
+			pending = current
+		default:
+			last = current
+			// Failures are reported through the hook; keep watching.
+			_ = h.Reload()
+		}
+	}
+}
+
+func (h *Holder) notify(event Event) {
+	if h.options.OnReload != nil {
+		h.options.OnReload(event)
+	}
+}
+
+// changes compares the addresses of two directories, either of which may
+// be nil.
+func changes(previous, current *directory.Directory) (added, removed, modified []int) {
+	var before, after map[int]models.Address
+	if previous != nil {
+		before = previous.Dataset().Addresses
+	}
+	if current != nil {
+		after = current.Dataset().Addresses
+	}
+
+	for zipCode, address := range after {
+		old, ok := before[zipCode]
+		switch {
+		case !ok:
+			added = append(added, zipCode)
+		case old != address:
+			modified = append(modified, zipCode)
+		}
+	}
+
+	for zipCode := range before {
+		if _, ok := after[zipCode]; !ok {
+			removed = append(removed, zipCode)
+		}
+	}
+
+	slices.Sort(added)
+	slices.Sort(removed)
+	slices.Sort(modified)
+
+	return added, removed, modified
+}
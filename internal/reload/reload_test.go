@@ -0,0 +1,164 @@
+package reload_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/reload"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func dataset(addresses ...models.Address) *models.Dataset {
+	m := map[int]models.Address{}
+	for _, a := range addresses {
+		m[a.ZipCode] = a
+	}
+	return &models.Dataset{Addresses: m}
+}
+
+func TestHolderReload(t *testing.T) {
+	releases := []*models.Dataset{
+		dataset(
+			models.Address{ZipCode: 1, Street: "Um"},
+			models.Address{ZipCode: 2, Street: "Dois"},
+			models.Address{ZipCode: 3, Street: "Três"},
+		),
+		dataset(
+			models.Address{ZipCode: 1, Street: "Um"},
+			models.Address{ZipCode: 2, Street: "Dois de Julho"},
+			models.Address{ZipCode: 4, Street: "Quatro"},
+		),
+	}
+
+	var (
+		loads  int
+		events []reload.Event
+	)
+
+	holder := reload.New(func() (*models.Dataset, error) {
+		if loads == len(releases) {
+			return nil, errors.New("broken release")
+		}
+		loads++
+		return releases[loads-1], nil
+	}, reload.WithOnReload(func(e reload.Event) {
+		events = append(events, e)
+	}))
+
+	require.Nil(t, holder.Directory())
+
+	require.NoError(t, holder.Reload())
+	first := holder.Directory()
+	require.Equal(t, 3, first.Len())
+	require.Equal(t, []int{1, 2, 3}, events[0].Added)
+	require.Nil(t, events[0].Previous)
+
+	require.NoError(t, holder.Reload())
+	second := holder.Directory()
+	require.Equal(t, []int{4}, events[1].Added)
+	require.Equal(t, []int{3}, events[1].Removed)
+	require.Equal(t, []int{2}, events[1].Modified)
+	require.Same(t, first, events[1].Previous)
+	require.Same(t, second, events[1].Current)
+
+	// The directory handed out before the swap is left untouched.
+	_, ok := first.LookupCEP(3)
+	require.True(t, ok)
+
+	require.Error(t, holder.Reload())
+	require.Same(t, second, holder.Directory())
+	require.Error(t, events[2].Err)
+	require.Same(t, second, events[2].Current)
+}
+
+func TestHolderReadersDuringReload(t *testing.T) {
+	holder := reload.New(reload.FromDirectories(test.Fixture("base"), test.Fixture("update")))
+	require.NoError(t, holder.Reload())
+
+	done := make(chan error, 1)
+	go func() {
+		var err error
+		for i := 0; i < 3 && err == nil; i++ {
+			err = holder.Reload()
+		}
+		done <- err
+	}()
+
+	for {
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+			return
+		default:
+			_, ok := holder.Directory().LookupCEP(6415235)
+			require.True(t, ok)
+		}
+	}
+}
+
+func TestHolderWatch(t *testing.T) {
+	dir := t.TempDir()
+	copyFile(t, test.Fixture("base"), dir, "LOG_LOGRADOURO_ES.TXT")
+
+	reloaded := make(chan reload.Event, 10)
+	holder := reload.New(reload.FromDirectories(dir, ""),
+		reload.WithPollInterval(10*time.Millisecond),
+		reload.WithOnReload(func(e reload.Event) { reloaded <- e }),
+	)
+	require.NoError(t, holder.Reload())
+	<-reloaded
+	before := holder.Directory().Len()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watching := make(chan error, 1)
+	go func() { watching <- holder.Watch(ctx, dir) }()
+
+	// Let Watch take its first look before the release changes.
+	time.Sleep(100 * time.Millisecond)
+	copyFile(t, test.Fixture("base"), dir, "LOG_LOGRADOURO_AC.TXT")
+
+	select {
+	case e := <-reloaded:
+		require.NoError(t, e.Err)
+		require.NotEmpty(t, e.Added)
+		require.Greater(t, holder.Directory().Len(), before)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reload after the directory changed")
+	}
+
+	cancel()
+	require.ErrorIs(t, <-watching, context.Canceled)
+}
+
+func TestFromArchives(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "eDNE_Basico_25011")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	copyFile(t, test.Fixture("base"), dir, "LOG_LOGRADOURO_ES.TXT")
+
+	dataset, err := reload.FromArchives(dir, "")()
+	require.NoError(t, err)
+	require.Equal(t, "eDNE_Basico_25011", dataset.Release.Base.Name)
+	require.Equal(t, "25011", dataset.Release.Base.Version)
+	require.Nil(t, dataset.Release.Update)
+
+	dataset, err = reload.FromArchives(test.Fixture("base"), test.Fixture("update"))()
+	require.NoError(t, err)
+	require.Equal(t, "update", dataset.Release.Update.Name)
+	require.Empty(t, dataset.Release.Update.Version)
+}
+
+func copyFile(t *testing.T, from, to, name string) {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(from, name))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(to, name), data, 0o644))
+}
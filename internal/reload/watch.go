@@ -0,0 +1,41 @@
+package reload
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+)
+
+// fingerprint hashes the names, sizes and modification times of the files
+// under paths.
+func fingerprint(paths []string) ([sha256.Size]byte, error) {
+	hash := sha256.New()
+
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(hash, "%s\x00%d\x00%d\n", path, info.Size(), info.ModTime().UnixNano())
+			return nil
+		})
+		if err != nil {
+			return [sha256.Size]byte{}, fmt.Errorf("error reading %s: %w", root, err)
+		}
+	}
+
+	var sum [sha256.Size]byte
+	hash.Sum(sum[:0])
+
+	return sum, nil
+}
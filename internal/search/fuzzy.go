@@ -0,0 +1,415 @@
+package search
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/NSXBet/edne/internal/models"
+)
+
+const defaultMatchLimit = 10
+
+// abbreviations expands the short forms used on envelopes and in the
+// Correios abbreviated names to the words stored in the eDNE.
+var abbreviations = map[string]string{
+	"al":    "alameda",
+	"av":    "avenida",
+	"ave":   "avenida",
+	"bc":    "beco",
+	"cap":   "capitao",
+	"cel":   "coronel",
+	"cj":    "conjunto",
+	"cjto":  "conjunto",
+	"cond":  "condominio",
+	"cons":  "conselheiro",
+	"dep":   "deputado",
+	"des":   "desembargador",
+	"dr":    "doutor",
+	"dra":   "doutora",
+	"eng":   "engenheiro",
+	"est":   "estrada",
+	"gal":   "general",
+	"gen":   "general",
+	"gov":   "governador",
+	"jd":    "jardim",
+	"lgo":   "largo",
+	"lot":   "loteamento",
+	"mal":   "marechal",
+	"maj":   "major",
+	"min":   "ministro",
+	"pca":   "praca",
+	"pc":    "praca",
+	"pe":    "padre",
+	"pq":    "parque",
+	"pref":  "prefeito",
+	"pres":  "presidente",
+	"prof":  "professor",
+	"profa": "professora",
+	"q":     "quadra",
+	"qd":    "quadra",
+	"r":     "rua",
+	"res":   "residencial",
+	"rod":   "rodovia",
+	"s":     "sao",
+	"sen":   "senador",
+	"sta":   "santa",
+	"sto":   "santo",
+	"st":    "setor",
+	"ten":   "tenente",
+	"tr":    "trecho",
+	"trav":  "travessa",
+	"tv":    "travessa",
+	"vl":    "vila",
+}
+
+var states = map[string]bool{
+	"ac": true, "al": true, "ap": true, "am": true, "ba": true, "ce": true, "df": true, "es": true, "go": true,
+	"ma": true, "mt": true, "ms": true, "mg": true, "pa": true, "pb": true, "pr": true, "pe": true, "pi": true,
+	"rj": true, "rn": true, "rs": true, "ro": true, "rr": true, "sc": true, "sp": true, "se": true, "to": true,
+}
+
+// IsState tells whether a normalized token is a UF, such as "sp".
+func IsState(token string) bool {
+	return states[token]
+}
+
+type Match struct {
+	Address models.Address
+	// Confidence goes from 0 to 1, where 1 means every part of the text
+	// matched the address exactly.
+	Confidence float64
+}
+
+// freeText is a free-form address split into the parts the matcher scores.
+// House numbers are left out, as the index holds no number ranges.
+type freeText struct {
+	street []string
+	city   string
+	state  string
+}
+
+// Match finds the addresses that best fit a free-form address such as
+// "av pres getulio varga, vitoria es", tolerating abbreviations, typos and
+// missing parts. Results are ordered by decreasing confidence.
+//
+// WithState, WithCity and WithNeighborhood restrict the results as in
+// Search, whereas a city or UF written in text only weighs on the
+// confidence.
+func (idx *Index) Match(text string, opts ...SearchOption) []Match {
+	options := &SearchOptions{Limit: defaultMatchLimit}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	query := idx.parseFreeText(text)
+	if len(query.street) == 0 {
+		return nil
+	}
+
+	candidates := map[int]bool{}
+	similarities := make([]map[int]float64, len(query.street))
+	for i, token := range query.street {
+		similarities[i] = idx.fuzzyMatch(token)
+		if idx.isStreetType(token) && len(query.street) > 1 {
+			continue
+		}
+		for id := range similarities[i] {
+			candidates[id] = true
+		}
+	}
+
+	state := strings.ToUpper(strings.TrimSpace(options.State))
+	city := Normalize(options.City)
+	neighborhood := Normalize(options.Neighborhood)
+
+	matches := make([]Match, 0, len(candidates))
+	for id := range candidates {
+		doc := idx.documents[id]
+		if state != "" && doc.address.State != state ||
+			city != "" && doc.city != city ||
+			neighborhood != "" && doc.neighborhood != neighborhood {
+			continue
+		}
+
+		var sum float64
+		for i := range query.street {
+			sum += similarities[i][id]
+		}
+		streetScore := 0.8*sum/float64(len(query.street)) +
+			0.2*math.Min(1, float64(len(query.street))/float64(len(doc.tokens)))
+
+		confidence, weight := 0.7*streetScore, 0.7
+		if query.city != "" {
+			confidence += 0.2 * similarity(query.city, doc.city)
+			weight += 0.2
+		}
+		if query.state != "" {
+			if strings.ToLower(doc.address.State) == query.state {
+				confidence += 0.1
+			}
+			weight += 0.1
+		}
+
+		matches = append(matches, Match{Address: doc.address, Confidence: confidence / weight})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Confidence != matches[j].Confidence {
+			return matches[i].Confidence > matches[j].Confidence
+		}
+		return matches[i].Address.ZipCode < matches[j].Address.ZipCode
+	})
+
+	if options.Limit > 0 && len(matches) > options.Limit {
+		matches = matches[:options.Limit]
+	}
+
+	return matches
+}
+
+// parseFreeText splits text into street words, city and UF, skipping house
+// numbers.
+// Comma separated parts after the first are read as city and UF; without
+// commas the trailing words are compared against the indexed city names.
+func (idx *Index) parseFreeText(text string) freeText {
+	var query freeText
+
+	parts := strings.Split(strings.NewReplacer(";", ",", "\n", ",").Replace(text), ",")
+
+	tokens := Tokens(parts[0])
+	var location []string
+	for _, part := range parts[1:] {
+		location = append(location, Tokens(part)...)
+	}
+
+	// A trailing UF may be glued to the street or city part.
+	if n := len(location); n > 0 && states[location[n-1]] {
+		query.state, location = location[n-1], location[:n-1]
+	} else if n := len(tokens); n > 1 && len(location) == 0 && states[tokens[n-1]] {
+		query.state, tokens = tokens[n-1], tokens[:n-1]
+	}
+
+	for len(location) > 0 && isNumber(location[0]) {
+		location = location[1:]
+	}
+
+	if len(location) == 0 && len(parts) == 1 {
+		tokens, location = idx.splitCity(tokens)
+	}
+	query.city = strings.Join(location, " ")
+
+	for _, token := range tokens {
+		if isNumber(token) {
+			continue
+		}
+		if expanded, ok := abbreviations[token]; ok {
+			token = expanded
+		}
+		query.street = append(query.street, token)
+	}
+
+	return query
+}
+
+// splitCity moves the longest run of trailing tokens resembling an indexed
+// city name out of the street words.
+func (idx *Index) splitCity(tokens []string) ([]string, []string) {
+	for i := 1; i < len(tokens); i++ {
+		candidate := strings.Join(tokens[i:], " ")
+		if idx.isCity(candidate) {
+			return tokens[:i], tokens[i:]
+		}
+	}
+
+	return tokens, nil
+}
+
+// maxCityMemo bounds the memo of isCity, which is emptied when full.
+const maxCityMemo = 4096
+
+func (idx *Index) isCity(name string) bool {
+	idx.cityMu.Lock()
+	found, ok := idx.cityMemo[name]
+	idx.cityMu.Unlock()
+	if ok {
+		return found
+	}
+
+	idx.cities().near(name, 2, func(city string, distance int) bool {
+		found = city != "" && distance <= maxEdits(city)
+		return !found
+	})
+
+	idx.cityMu.Lock()
+	if idx.cityMemo == nil || len(idx.cityMemo) >= maxCityMemo {
+		idx.cityMemo = map[string]bool{}
+	}
+	idx.cityMemo[name] = found
+	idx.cityMu.Unlock()
+
+	return found
+}
+
+func (idx *Index) cities() lexicon {
+	idx.citiesOnce.Do(func() {
+		names := map[string]bool{}
+		for _, doc := range idx.documents {
+			names[doc.city] = true
+		}
+		idx.cityNames = newLexicon(slices.Sorted(maps.Keys(names)))
+	})
+
+	return idx.cityNames
+}
+
+func (idx *Index) isStreetType(token string) bool {
+	idx.typesOnce.Do(func() {
+		idx.streetTypes = map[string]bool{}
+		for _, doc := range idx.documents {
+			for _, t := range Tokens(doc.address.StreetType) {
+				idx.streetTypes[t] = true
+			}
+		}
+	})
+
+	return idx.streetTypes[token]
+}
+
+// fuzzyMatch scores every document containing a word close to token: whole
+// words score 1, prefixes the covered fraction and words within the allowed
+// edit distance lose a share per edit.
+func (idx *Index) fuzzyMatch(token string) map[int]float64 {
+	matches := idx.match(token)
+	for id, score := range matches {
+		if score < 1 {
+			matches[id] = 0.9 * score
+		}
+	}
+
+	allowed := maxEdits(token)
+	if allowed == 0 {
+		return matches
+	}
+
+	idx.words.near(token, allowed, func(term string, distance int) bool {
+		if term == token {
+			return true
+		}
+
+		score := 1 - float64(distance)/float64(max(len(term), len(token)))
+		for _, id := range idx.postings[term] {
+			if score > matches[id] {
+				matches[id] = score
+			}
+		}
+		return true
+	})
+
+	return matches
+}
+
+// maxEdits is the number of typos tolerated in a word of that length.
+func maxEdits(s string) int {
+	switch n := len(s); {
+	case n <= 3:
+		return 0
+	case n <= 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func similarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	if a == "" || b == "" {
+		return 0
+	}
+	return 1 - float64(levenshtein(a, b))/float64(max(len(a), len(b)))
+}
+
+// lexicon holds words by their length in runes, so the words within a few
+// edits of another are found by comparing it only with those of a close
+// enough length.
+type lexicon map[int][]lexiconWord
+
+type lexiconWord struct {
+	text  string
+	runes []rune
+}
+
+func newLexicon(words []string) lexicon {
+	l := lexicon{}
+	for _, word := range words {
+		runes := []rune(word)
+		l[len(runes)] = append(l[len(runes)], lexiconWord{word, runes})
+	}
+	return l
+}
+
+// near calls fn with every word within limit edits of word and its
+// distance, until fn returns false.
+func (l lexicon) near(word string, limit int, fn func(text string, distance int) bool) {
+	runes := []rune(word)
+	rows := make([]int, 2*(len(runes)+1))
+
+	for n := max(0, len(runes)-limit); n <= len(runes)+limit; n++ {
+		for _, w := range l[n] {
+			if distance := editDistance(w.runes, runes, limit, rows); distance <= limit {
+				if !fn(w.text, distance) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// levenshtein is the edit distance between two normalized strings.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	return editDistance(ra, rb, max(len(ra), len(rb)), make([]int, 2*(len(rb)+1)))
+}
+
+// editDistance is the Levenshtein distance between a and b, or more than
+// limit as soon as it is known to exceed it. rows holds two rows of
+// len(b)+1 cells, so that no call allocates.
+func editDistance(a, b []rune, limit int, rows []int) int {
+	previous, current := rows[:len(b)+1], rows[len(b)+1:2*(len(b)+1)]
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		best := i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			best = min(best, current[j])
+		}
+		if best > limit {
+			return limit + 1
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+func isNumber(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return s != ""
+}
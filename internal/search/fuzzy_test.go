@@ -0,0 +1,85 @@
+package search_test
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/internal/search"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchFixtures(t *testing.T) {
+	addresses, err := parser.NewMasterParser().Parse(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	idx := search.NewIndex(maps.All(addresses))
+
+	// 100030@ES@2044@1752@@Presidente Getúlio Vargas@@29127225@Avenida@S@Av Pres Getúlio Vargas
+	for _, text := range []string{
+		"av pres getulio varga, vitoria es",
+		"Avenida Presidente Getúlio Vargas",
+		"avenida presidnte getulio vargs 120 es",
+	} {
+		matches := idx.Match(text)
+		require.NotEmpty(t, matches, text)
+		require.Equal(t, 29127225, matches[0].Address.ZipCode, text)
+	}
+
+	// 1047349@AC@16@55416@@Lua Azul@@69909052@Rua@S@R Lua Azul
+	matches := idx.Match("r lua azul, 45, rio branco - ac")
+	require.NotEmpty(t, matches)
+	require.Equal(t, 69909052, matches[0].Address.ZipCode)
+	require.Greater(t, matches[0].Confidence, 0.95)
+
+	require.Empty(t, idx.Match(""))
+	require.Empty(t, idx.Match(", es"))
+}
+
+func TestMatchConfidence(t *testing.T) {
+	addresses := map[int]models.Address{
+		1: {ZipCode: 1, StreetType: "Avenida", Street: "Presidente Getúlio Vargas", City: "Vitória", State: "ES"},
+		2: {ZipCode: 2, StreetType: "Avenida", Street: "Presidente Getúlio Vargas", City: "Vila Velha", State: "ES"},
+		3: {ZipCode: 3, StreetType: "Rua", Street: "Getúlio Vargas", City: "Vitória", State: "ES"},
+		4: {ZipCode: 4, StreetType: "Avenida", Street: "Presidente Getúlio Vargas", City: "Curitiba", State: "PR"},
+	}
+	idx := search.NewIndex(maps.All(addresses))
+
+	matches := idx.Match("av pres getulio varga, vitoria es")
+	require.Len(t, matches, 4)
+	require.Equal(t, 1, matches[0].Address.ZipCode)
+	for i := 1; i < len(matches); i++ {
+		require.GreaterOrEqual(t, matches[i-1].Confidence, matches[i].Confidence)
+	}
+	require.Equal(t, 2, matches[1].Address.ZipCode, "same street in another city of the UF")
+
+	// Without commas the city is recognized from the indexed names.
+	matches = idx.Match("av pres getulio vargas vila velha")
+	require.Equal(t, 2, matches[0].Address.ZipCode)
+
+	matches = idx.Match("getulio vargas", search.WithState("PR"), search.WithLimit(1))
+	require.Len(t, matches, 1)
+	require.Equal(t, 4, matches[0].Address.ZipCode)
+
+	// Options filter even better matches out, whereas the text only scores.
+	matches = idx.Match("av pres getulio varga, vitoria es", search.WithState("PR"))
+	require.Len(t, matches, 1)
+	require.Equal(t, 4, matches[0].Address.ZipCode)
+
+	matches = idx.Match("av pres getulio varga, vitoria es", search.WithCity("vila velha"))
+	require.Len(t, matches, 1)
+	require.Equal(t, 2, matches[0].Address.ZipCode)
+}
+
+func BenchmarkMatch(b *testing.B) {
+	addresses, err := parser.NewMasterParser().Parse(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(b, err)
+
+	idx := search.NewIndex(maps.All(addresses))
+
+	for range b.N {
+		idx.Match("avenida presidnte getulio vargs 120 vitoria es")
+	}
+}
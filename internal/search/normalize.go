@@ -0,0 +1,44 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize folds s to lower case without diacritics, keeping only letters
+// and digits separated by single spaces, so "Getúlio-Vargas" and
+// "GETULIO VARGAS" compare equal.
+func Normalize(s string) string {
+	return strings.Join(Tokens(s), " ")
+}
+
+// Tokens returns the normalized words of s.
+func Tokens(s string) []string {
+	t := transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, s)
+	if err != nil {
+		folded = s
+	}
+
+	return strings.FieldsFunc(strings.ToLower(folded), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Expand normalizes s and spells out the abbreviations in it, so that
+// "Av. Pres. Getúlio Vargas" and "Avenida Presidente Getulio Vargas" compare
+// equal.
+func Expand(s string) string {
+	tokens := Tokens(s)
+	for i, token := range tokens {
+		if expanded, ok := abbreviations[token]; ok {
+			tokens[i] = expanded
+		}
+	}
+
+	return strings.Join(tokens, " ")
+}
@@ -0,0 +1,219 @@
+package search
+
+import (
+	"iter"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/NSXBet/edne/internal/models"
+)
+
+const defaultLimit = 20
+
+type SearchOption func(opts *SearchOptions)
+
+type SearchOptions struct {
+	State        string
+	City         string
+	Neighborhood string
+	Limit        int
+}
+
+// WithState restricts results to a UF.
+func WithState(state string) SearchOption {
+	return func(opts *SearchOptions) {
+		opts.State = state
+	}
+}
+
+// WithCity restricts results to a city, compared without accents or case.
+func WithCity(city string) SearchOption {
+	return func(opts *SearchOptions) {
+		opts.City = city
+	}
+}
+
+// WithNeighborhood restricts results to a neighborhood, compared without
+// accents or case.
+func WithNeighborhood(neighborhood string) SearchOption {
+	return func(opts *SearchOptions) {
+		opts.Neighborhood = neighborhood
+	}
+}
+
+// WithLimit caps the number of results. Zero or less returns every match.
+func WithLimit(limit int) SearchOption {
+	return func(opts *SearchOptions) {
+		opts.Limit = limit
+	}
+}
+
+type Result struct {
+	Address models.Address
+	Score   float64
+}
+
+type document struct {
+	address      models.Address
+	name         string
+	tokens       []string
+	city         string
+	neighborhood string
+}
+
+// Index is an inverted index over street names and types.
+type Index struct {
+	documents []document
+	postings  map[string][]int
+	terms     []string
+	words     lexicon
+
+	citiesOnce  sync.Once
+	cityNames   lexicon
+	typesOnce   sync.Once
+	streetTypes map[string]bool
+
+	// cityMemo remembers which words name a city, as every trailing run of
+	// words of a query is tried.
+	cityMu   sync.Mutex
+	cityMemo map[string]bool
+}
+
+// NewIndex indexes addresses. Documents are numbered in iteration order, so
+// an ordered sequence gives results with a stable tie-break.
+func NewIndex(addresses iter.Seq2[int, models.Address]) *Index {
+	idx := &Index{postings: map[string][]int{}}
+
+	for _, address := range addresses {
+		id := len(idx.documents)
+		name := Tokens(address.Street)
+		tokens := append(Tokens(address.StreetType), name...)
+
+		idx.documents = append(idx.documents, document{
+			address:      address,
+			name:         strings.Join(name, " "),
+			tokens:       tokens,
+			city:         Normalize(address.City),
+			neighborhood: Normalize(address.Neighborhood),
+		})
+
+		for _, token := range tokens {
+			postings := idx.postings[token]
+			if len(postings) == 0 || postings[len(postings)-1] != id {
+				idx.postings[token] = append(postings, id)
+			}
+		}
+	}
+
+	idx.terms = make([]string, 0, len(idx.postings))
+	for term := range idx.postings {
+		idx.terms = append(idx.terms, term)
+	}
+	sort.Strings(idx.terms)
+	idx.words = newLexicon(idx.terms)
+
+	return idx
+}
+
+// Search returns the addresses whose street type and name contain every word
+// of text, either whole or as a prefix, best matches first.
+func (idx *Index) Search(text string, opts ...SearchOption) []Result {
+	options := &SearchOptions{Limit: defaultLimit}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	query := Tokens(text)
+	if len(query) == 0 {
+		return nil
+	}
+
+	var scores map[int]float64
+	for _, token := range query {
+		matches := idx.match(token)
+
+		if scores == nil {
+			scores = matches
+			continue
+		}
+
+		for id, score := range scores {
+			if m, ok := matches[id]; ok {
+				scores[id] = score + m
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	state := strings.ToUpper(strings.TrimSpace(options.State))
+	city := Normalize(options.City)
+	neighborhood := Normalize(options.Neighborhood)
+	phrase := strings.Join(query, " ")
+
+	results := make([]Result, 0, len(scores))
+	ids := make([]int, 0, len(scores))
+	for id, score := range scores {
+		doc := idx.documents[id]
+		if state != "" && doc.address.State != state ||
+			city != "" && doc.city != city ||
+			neighborhood != "" && doc.neighborhood != neighborhood {
+			continue
+		}
+
+		score /= float64(len(query))
+		switch {
+		case doc.name == phrase:
+			score += 1
+		case strings.HasPrefix(doc.name, phrase):
+			score += 0.5
+		case strings.Contains(doc.name, phrase):
+			score += 0.25
+		}
+		// Prefer names with fewer extra words.
+		score -= float64(len(doc.tokens)-len(query)) * 0.01
+
+		scores[id] = score
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	if options.Limit > 0 && len(ids) > options.Limit {
+		ids = ids[:options.Limit]
+	}
+
+	for _, id := range ids {
+		results = append(results, Result{Address: idx.documents[id].address, Score: scores[id]})
+	}
+
+	return results
+}
+
+// match scores every document containing token: 1 for a whole word, and the
+// covered fraction of the word for a prefix.
+func (idx *Index) match(token string) map[int]float64 {
+	matches := map[int]float64{}
+
+	for i := sort.SearchStrings(idx.terms, token); i < len(idx.terms); i++ {
+		term := idx.terms[i]
+		if !strings.HasPrefix(term, token) {
+			break
+		}
+
+		score := float64(len(token)) / float64(len(term))
+		for _, id := range idx.postings[term] {
+			if score > matches[id] {
+				matches[id] = score
+			}
+		}
+	}
+
+	return matches
+}
@@ -0,0 +1,85 @@
+package search_test
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/internal/search"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	require.Equal(t, "getulio vargas", search.Normalize("GETÚLIO VARGAS"))
+	require.Equal(t, "presidente getulio vargas", search.Normalize("  Presidente Getúlio-Vargas "))
+	require.Equal(t, "1o de maio", search.Normalize("1º de Maio"))
+	require.Equal(t, []string{"sao", "joao", "d", "agua"}, search.Tokens("São João D'Água"))
+}
+
+func TestSearchFixtures(t *testing.T) {
+	addresses, err := parser.NewMasterParser().Parse(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	idx := search.NewIndex(maps.All(addresses))
+
+	// 100030@ES@2044@1752@@Presidente Getúlio Vargas@@29127225@Avenida@S@Av Pres Getúlio Vargas
+	for _, query := range []string{"Getulio Vargas", "GETÚLIO VARGAS", "vargas"} {
+		zipCodes := []int{}
+		for _, result := range idx.Search(query) {
+			zipCodes = append(zipCodes, result.Address.ZipCode)
+		}
+		require.Contains(t, zipCodes, 29127225, query)
+		require.Contains(t, zipCodes, 38200051, query)
+	}
+
+	results := idx.Search("av pres getu")
+	require.Len(t, results, 1)
+	require.Equal(t, 29127225, results[0].Address.ZipCode)
+
+	results = idx.Search("Getulio Vargas", search.WithState("es"))
+	require.Len(t, results, 1)
+	require.Equal(t, "Presidente Getúlio Vargas", results[0].Address.Street)
+
+	require.Empty(t, idx.Search(""))
+	require.Empty(t, idx.Search("xyzzy"))
+}
+
+func TestSearchRankingAndFilters(t *testing.T) {
+	addresses := []models.Address{
+		{ZipCode: 1, StreetType: "Rua", Street: "Presidente Getúlio Vargas", City: "Vitória", State: "ES", Neighborhood: "Centro"},
+		{ZipCode: 2, StreetType: "Avenida", Street: "Getúlio Vargas", City: "São Paulo", State: "SP", Neighborhood: "Bela Vista"},
+		{ZipCode: 3, StreetType: "Rua", Street: "Getulina", City: "São Paulo", State: "SP", Neighborhood: "Centro"},
+		{ZipCode: 4, StreetType: "Rua", Street: "Vargas", City: "São Paulo", State: "SP", Neighborhood: "Centro"},
+	}
+	idx := search.NewIndex(func(yield func(int, models.Address) bool) {
+		for _, a := range addresses {
+			if !yield(a.ZipCode, a) {
+				return
+			}
+		}
+	})
+
+	results := idx.Search("getulio vargas")
+	require.Len(t, results, 2)
+	require.Equal(t, 2, results[0].Address.ZipCode, "exact name ranks first")
+	require.Equal(t, 1, results[1].Address.ZipCode)
+	require.Greater(t, results[0].Score, results[1].Score)
+
+	results = idx.Search("getul")
+	require.Len(t, results, 3)
+	require.Equal(t, 2, results[0].Address.ZipCode, "closest prefix ranks first")
+	require.Equal(t, 3, results[1].Address.ZipCode)
+
+	results = idx.Search("getulio", search.WithCity("SAO PAULO"))
+	require.Len(t, results, 1)
+	require.Equal(t, 2, results[0].Address.ZipCode)
+
+	results = idx.Search("getul", search.WithNeighborhood("centro"), search.WithState("ES"))
+	require.Len(t, results, 1)
+	require.Equal(t, 1, results[0].Address.ZipCode)
+
+	require.Len(t, idx.Search("rua", search.WithLimit(2)), 2)
+	require.Len(t, idx.Search("rua", search.WithLimit(0)), 3)
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/NSXBet/edne/internal/compat"
+	"github.com/NSXBet/edne/internal/directory"
+	"github.com/NSXBet/edne/internal/models"
+)
+
+// handleViaCEP mirrors viacep.com.br/ws/{cep}/{json,xml}/: unknown CEPs
+// answer 200 with "erro", malformed ones 400.
+func (s *Server) handleViaCEP(format string) func(http.ResponseWriter, *http.Request, *directory.Directory) {
+	write, contentType := compat.WriteViaCEPJSON, "application/json; charset=utf-8"
+	if format == "xml" {
+		write, contentType = compat.WriteViaCEPXML, "application/xml; charset=utf-8"
+	}
+
+	return func(w http.ResponseWriter, r *http.Request, d *directory.Directory) {
+		zipCode, err := models.ParseZipCode(r.PathValue("cep"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var v any = compat.NewViaCEPError()
+		address, found := d.LookupCEP(zipCode)
+		if found {
+			var opts []compat.ViaCEPOption
+			if street, ok := d.Street(zipCode); ok {
+				opts = append(opts, compat.WithComplement(street.Complement))
+			}
+			v = compat.NewViaCEP(address, opts...)
+		}
+
+		var body bytes.Buffer
+		if err := write(&body, v); err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if !found {
+			writeUncached(w, http.StatusOK, contentType, body.Bytes())
+			return
+		}
+		writeBody(w, r, http.StatusOK, contentType, body.Bytes())
+	}
+}
+
+// handleBrasilAPI mirrors BrasilAPI's /api/cep/v1/{cep}.
+func (s *Server) handleBrasilAPI(w http.ResponseWriter, r *http.Request, d *directory.Directory) {
+	zipCode, err := models.ParseZipCode(r.PathValue("cep"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	address, found := d.LookupCEP(zipCode)
+	v := any(compat.NewBrasilAPIError())
+	if found {
+		v = compat.NewBrasilAPI(address)
+	}
+
+	var body bytes.Buffer
+	if err := compat.WriteBrasilAPIJSON(&body, v); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if !found {
+		writeUncached(w, http.StatusNotFound, "application/json; charset=utf-8", body.Bytes())
+		return
+	}
+	writeBody(w, r, http.StatusOK, "application/json; charset=utf-8", body.Bytes())
+}
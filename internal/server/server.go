@@ -0,0 +1,367 @@
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/NSXBet/edne/internal/directory"
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/search"
+)
+
+const (
+	cacheControl   = "public, max-age=86400"
+	maxSearchLimit = 100
+)
+
+type Address struct {
+	ZipCode      string `json:"cep"`
+	StreetType   string `json:"street_type"`
+	Street       string `json:"street"`
+	Neighborhood string `json:"neighborhood"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+	CityIBGECode string `json:"city_ibge_code"`
+}
+
+type SearchResult struct {
+	Address
+	Score float64 `json:"score"`
+}
+
+type Locality struct {
+	ID           int    `json:"id"`
+	State        string `json:"state"`
+	Name         string `json:"name"`
+	ZipCode      string `json:"cep,omitempty"`
+	Type         string `json:"type"`
+	Abbreviation string `json:"abbreviation,omitempty"`
+	IBGECode     string `json:"ibge_code,omitempty"`
+}
+
+type Neighborhood struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Abbreviation string `json:"abbreviation,omitempty"`
+}
+
+type Release struct {
+	Base     ReleaseSource  `json:"base"`
+	Update   *ReleaseSource `json:"update,omitempty"`
+	LoadedAt time.Time      `json:"loaded_at"`
+	Duration string         `json:"load_duration"`
+}
+
+type ReleaseSource struct {
+	Name    string        `json:"name"`
+	Version string        `json:"version,omitempty"`
+	Date    time.Time     `json:"date"`
+	Rows    int           `json:"rows"`
+	Files   []ReleaseFile `json:"files"`
+}
+
+type ReleaseFile struct {
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	Rows     int    `json:"rows"`
+	SHA256   string `json:"sha256"`
+	Format   string `json:"format"`
+	Encoding string `json:"encoding"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server serves CEP lookups over HTTP. It reports not ready until a
+// directory is set.
+type Server struct {
+	directory atomic.Pointer[directory.Directory]
+	mux       *http.ServeMux
+}
+
+func New() *Server {
+	s := &Server{mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("GET /cep/{cep}", s.ready(s.handleCEP))
+	s.mux.HandleFunc("GET /search", s.ready(s.handleSearch))
+	s.mux.HandleFunc("GET /localities", s.ready(s.handleLocalities))
+	s.mux.HandleFunc("GET /localities/{id}", s.ready(s.handleLocality))
+	s.mux.HandleFunc("GET /localities/{id}/neighborhoods", s.ready(s.handleNeighborhoods))
+	s.mux.HandleFunc("GET /ws/{cep}/json/", s.ready(s.handleViaCEP("json")))
+	s.mux.HandleFunc("GET /ws/{cep}/xml/", s.ready(s.handleViaCEP("xml")))
+	s.mux.HandleFunc("GET /api/cep/v1/{cep}", s.ready(s.handleBrasilAPI))
+	s.mux.HandleFunc("GET /release", s.ready(s.handleRelease))
+	s.mux.HandleFunc("GET /healthz", s.handleHealth)
+	s.mux.HandleFunc("GET /readyz", s.handleReady)
+
+	return s
+}
+
+// SetDirectory makes the server ready, serving lookups from d.
+func (s *Server) SetDirectory(d *directory.Directory) {
+	s.directory.Store(d)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) ready(next func(http.ResponseWriter, *http.Request, *directory.Directory)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		d := s.directory.Load()
+		if d == nil {
+			writeError(w, http.StatusServiceUnavailable, "dataset not loaded")
+			return
+		}
+		next(w, r, d)
+	}
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	if s.directory.Load() == nil {
+		writeError(w, http.StatusServiceUnavailable, "dataset not loaded")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
+
+func (s *Server) handleCEP(w http.ResponseWriter, r *http.Request, d *directory.Directory) {
+	zipCode, err := models.ParseZipCode(r.PathValue("cep"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	address, ok := d.LookupCEP(zipCode)
+	if !ok {
+		writeError(w, http.StatusNotFound, "CEP not found")
+		return
+	}
+
+	writeCached(w, r, NewAddress(address))
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request, d *directory.Directory) {
+	query := r.URL.Query()
+
+	text := strings.TrimSpace(query.Get("q"))
+	if text == "" {
+		writeError(w, http.StatusBadRequest, "missing q parameter")
+		return
+	}
+
+	limit := 20
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxSearchLimit {
+			writeError(w, http.StatusBadRequest, "limit must be between 1 and 100")
+			return
+		}
+		limit = n
+	}
+
+	opts := []search.SearchOption{
+		search.WithState(query.Get("state")),
+		search.WithCity(query.Get("city")),
+		search.WithNeighborhood(query.Get("neighborhood")),
+		search.WithLimit(limit),
+	}
+
+	results := []SearchResult{}
+	for _, result := range d.Search(text, opts...) {
+		results = append(results, SearchResult{Address: NewAddress(result.Address), Score: result.Score})
+	}
+
+	writeCached(w, r, results)
+}
+
+func (s *Server) handleLocalities(w http.ResponseWriter, r *http.Request, d *directory.Directory) {
+	state := strings.TrimSpace(r.URL.Query().Get("state"))
+	if len(state) != 2 {
+		writeError(w, http.StatusBadRequest, "state must be a two letter UF")
+		return
+	}
+
+	localities := []Locality{}
+	for _, location := range d.LocalitiesInState(state) {
+		localities = append(localities, NewLocality(location))
+	}
+
+	writeCached(w, r, localities)
+}
+
+func (s *Server) handleLocality(w http.ResponseWriter, r *http.Request, d *directory.Directory) {
+	location, ok := s.locality(w, r, d)
+	if !ok {
+		return
+	}
+
+	writeCached(w, r, NewLocality(location))
+}
+
+func (s *Server) handleNeighborhoods(w http.ResponseWriter, r *http.Request, d *directory.Directory) {
+	location, ok := s.locality(w, r, d)
+	if !ok {
+		return
+	}
+
+	neighborhoods := []Neighborhood{}
+	for _, n := range d.NeighborhoodsInLocality(location.ID) {
+		neighborhoods = append(neighborhoods, Neighborhood{ID: n.ID, Name: n.Name, Abbreviation: n.Abbreviation})
+	}
+
+	writeCached(w, r, neighborhoods)
+}
+
+func (s *Server) handleRelease(w http.ResponseWriter, r *http.Request, d *directory.Directory) {
+	release := d.Dataset().Release
+	if release == nil {
+		writeError(w, http.StatusNotFound, "release not recorded")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, NewRelease(*release))
+}
+
+func (s *Server) locality(w http.ResponseWriter, r *http.Request, d *directory.Directory) (models.Location, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid locality id")
+		return models.Location{}, false
+	}
+
+	location, ok := d.Locality(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "locality not found")
+		return models.Location{}, false
+	}
+
+	return location, true
+}
+
+func NewAddress(a models.Address) Address {
+	return Address{
+		ZipCode:      models.FormatZipCode(a.ZipCode),
+		StreetType:   a.StreetType,
+		Street:       a.Street,
+		Neighborhood: a.Neighborhood,
+		City:         a.City,
+		State:        a.State,
+		CityIBGECode: a.CityIBGECode,
+	}
+}
+
+func NewLocality(l models.Location) Locality {
+	locality := Locality{
+		ID:           l.ID,
+		State:        l.State,
+		Name:         l.Name,
+		Type:         string(l.Type),
+		Abbreviation: l.Abbreviation,
+		IBGECode:     l.IBGECode,
+	}
+	if l.ZipCode != 0 {
+		locality.ZipCode = models.FormatZipCode(l.ZipCode)
+	}
+	return locality
+}
+
+func NewRelease(r models.Release) Release {
+	release := Release{
+		Base:     newReleaseSource(r.Base),
+		LoadedAt: r.LoadedAt,
+		Duration: r.Duration.String(),
+	}
+	if r.Update != nil {
+		update := newReleaseSource(*r.Update)
+		release.Update = &update
+	}
+	return release
+}
+
+func newReleaseSource(s models.Source) ReleaseSource {
+	source := ReleaseSource{
+		Name:    s.Name,
+		Version: s.Version,
+		Date:    s.Date,
+		Rows:    s.Rows(),
+		Files:   make([]ReleaseFile, len(s.Files)),
+	}
+	for i, f := range s.Files {
+		source.Files[i] = ReleaseFile(f)
+	}
+	return source
+}
+
+// writeCached writes v with an ETag derived from the body, answering 304
+// when the client already holds it.
+func writeCached(w http.ResponseWriter, r *http.Request, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeBody(w, r, http.StatusOK, "application/json; charset=utf-8", append(body, '\n'))
+}
+
+func writeBody(w http.ResponseWriter, r *http.Request, status int, contentType string, body []byte) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", cacheControl)
+
+	if match := r.Header.Get("If-None-Match"); match != "" && etagMatches(match, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// writeUncached writes the answer for a CEP missing from the release, which
+// caches must not keep: the next release may add it.
+func writeUncached(w http.ResponseWriter, status int, contentType string, body []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorResponse{Error: message})
+}
@@ -0,0 +1,160 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/directory"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/internal/server"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func newServer(t *testing.T) *server.Server {
+	t.Helper()
+
+	dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	s := server.New()
+	s.SetDirectory(directory.New(dataset))
+
+	return s
+}
+
+func get(t *testing.T, s http.Handler, target string, header ...string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		r.Header.Set(header[i], header[i+1])
+	}
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestServerCEP(t *testing.T) {
+	s := newServer(t)
+
+	w := get(t, s, "/cep/06415-235")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotEmpty(t, w.Header().Get("ETag"))
+	require.Contains(t, w.Header().Get("Cache-Control"), "max-age")
+
+	var address server.Address
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &address))
+	require.Equal(t, "06415235", address.ZipCode)
+	require.Equal(t, "São Bento", address.Street)
+	require.Equal(t, "Mosteiro Bento", address.City)
+
+	w = get(t, s, "/cep/06415235", "If-None-Match", w.Header().Get("ETag"))
+	require.Equal(t, http.StatusNotModified, w.Code)
+	require.Empty(t, w.Body.Bytes())
+
+	require.Equal(t, http.StatusNotFound, get(t, s, "/cep/99999999").Code)
+	require.Equal(t, http.StatusBadRequest, get(t, s, "/cep/1234").Code)
+	require.Equal(t, http.StatusBadRequest, get(t, s, "/cep/abcdefgh").Code)
+}
+
+func TestServerSearch(t *testing.T) {
+	s := newServer(t)
+
+	w := get(t, s, "/search?q=getulio+vargas&state=ES")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var results []server.SearchResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &results))
+	require.Len(t, results, 1)
+	require.Equal(t, "29127225", results[0].ZipCode)
+
+	require.Equal(t, http.StatusBadRequest, get(t, s, "/search").Code)
+	require.Equal(t, http.StatusBadRequest, get(t, s, "/search?q=vargas&limit=0").Code)
+}
+
+func TestServerLocalities(t *testing.T) {
+	s := newServer(t)
+
+	w := get(t, s, "/localities?state=ac")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var localities []server.Locality
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &localities))
+	require.Len(t, localities, 20)
+	require.Equal(t, "Acrelândia", localities[0].Name)
+
+	w = get(t, s, "/localities/16")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var locality server.Locality
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &locality))
+	require.Equal(t, "Rio Branco", locality.Name)
+
+	w = get(t, s, "/localities/16/neighborhoods")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var neighborhoods []server.Neighborhood
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &neighborhoods))
+	require.NotEmpty(t, neighborhoods)
+
+	require.Equal(t, http.StatusBadRequest, get(t, s, "/localities").Code)
+	require.Equal(t, http.StatusBadRequest, get(t, s, "/localities/abc").Code)
+	require.Equal(t, http.StatusNotFound, get(t, s, "/localities/999999").Code)
+}
+
+func TestServerReadiness(t *testing.T) {
+	s := server.New()
+
+	require.Equal(t, http.StatusOK, get(t, s, "/healthz").Code)
+	require.Equal(t, http.StatusServiceUnavailable, get(t, s, "/readyz").Code)
+	require.Equal(t, http.StatusServiceUnavailable, get(t, s, "/cep/06415235").Code)
+
+	s = newServer(t)
+	require.Equal(t, http.StatusOK, get(t, s, "/readyz").Code)
+}
+
+func TestServerRelease(t *testing.T) {
+	w := get(t, newServer(t), "/release")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var release server.Release
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &release))
+	require.Equal(t, "base", release.Base.Name)
+	require.Equal(t, "update", release.Update.Name)
+	require.Equal(t, 304, release.Base.Rows)
+	require.NotEmpty(t, release.Base.Files[0].SHA256)
+}
+
+func TestServerCompat(t *testing.T) {
+	s := newServer(t)
+
+	w := get(t, s, "/ws/06415235/json/")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"logradouro": "Rua São Bento"`)
+	require.Contains(t, w.Body.String(), `"cep": "06415-235"`)
+
+	w = get(t, s, "/ws/99999999/json/")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"erro": true}`, w.Body.String())
+	require.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+	w = get(t, s, "/ws/06415235/xml/")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Header().Get("Content-Type"), "xml")
+	require.Contains(t, w.Body.String(), "<localidade>Mosteiro Bento</localidade>")
+
+	require.Equal(t, http.StatusBadRequest, get(t, s, "/ws/123/json/").Code)
+
+	w = get(t, s, "/api/cep/v1/06415235")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"neighborhood":"Cruz de São Bento"`)
+
+	w = get(t, s, "/api/cep/v1/99999999")
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+}
@@ -0,0 +1,162 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+
+	"github.com/NSXBet/edne/internal/models"
+)
+
+// FormatVersion must be bumped whenever the encoded models change shape, so
+// snapshots written by an incompatible library are refused instead of being
+// decoded into the wrong fields.
+const FormatVersion uint16 = 5
+
+var magic = [8]byte{'E', 'D', 'N', 'E', 'S', 'N', 'A', 'P'}
+
+var (
+	ErrInvalidSnapshot     = errors.New("not an edne snapshot")
+	ErrIncompatibleVersion = errors.New("incompatible snapshot version")
+	ErrChecksumMismatch    = errors.New("snapshot checksum mismatch")
+)
+
+// header precedes the gob encoded dataset. The SHA-256 checksum of the
+// payload follows it.
+type header struct {
+	Magic       [8]byte
+	Version     uint16
+	PayloadSize uint64
+}
+
+// Write encodes dataset into w. Only the entities are stored, not the
+// lookup and search indexes: directory.New rebuilds those from the loaded
+// dataset in memory. That costs far less than the parsing a snapshot saves
+// (see BenchmarkLoad and BenchmarkParse), and keeps the format independent
+// of how the directory indexes are laid out.
+func Write(w io.Writer, dataset *models.Dataset) error {
+	var payload bytes.Buffer
+	if err := gob.NewEncoder(&payload).Encode(dataset); err != nil {
+		return fmt.Errorf("error encoding dataset: %w", err)
+	}
+
+	h := header{Magic: magic, Version: FormatVersion, PayloadSize: uint64(payload.Len())}
+	if err := binary.Write(w, binary.LittleEndian, h); err != nil {
+		return fmt.Errorf("error writing header: %w", err)
+	}
+
+	checksum := sha256.Sum256(payload.Bytes())
+
+	if _, err := payload.WriteTo(w); err != nil {
+		return fmt.Errorf("error writing payload: %w", err)
+	}
+
+	if _, err := w.Write(checksum[:]); err != nil {
+		return fmt.Errorf("error writing checksum: %w", err)
+	}
+
+	return nil
+}
+
+// Read decodes a dataset written by Write, verifying its version and checksum
+// before decoding the payload.
+func Read(r io.Reader) (*models.Dataset, error) {
+	var h header
+	if err := binary.Read(r, binary.LittleEndian, &h); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSnapshot, err)
+	}
+
+	if h.Magic != magic {
+		return nil, ErrInvalidSnapshot
+	}
+
+	if h.Version != FormatVersion {
+		return nil, fmt.Errorf("%w: got %d, want %d", ErrIncompatibleVersion, h.Version, FormatVersion)
+	}
+
+	if h.PayloadSize > math.MaxInt64 {
+		return nil, fmt.Errorf("%w: payload of %d bytes", ErrInvalidSnapshot, h.PayloadSize)
+	}
+
+	// The header is not trusted with the allocation: the buffer only grows
+	// as the payload is actually read.
+	var payload bytes.Buffer
+	if _, err := io.CopyN(&payload, r, int64(h.PayloadSize)); err != nil {
+		return nil, fmt.Errorf("%w: error reading payload: %w", ErrInvalidSnapshot, err)
+	}
+
+	var checksum [sha256.Size]byte
+	if _, err := io.ReadFull(r, checksum[:]); err != nil {
+		return nil, fmt.Errorf("%w: error reading checksum: %w", ErrInvalidSnapshot, err)
+	}
+
+	if sha256.Sum256(payload.Bytes()) != checksum {
+		return nil, ErrChecksumMismatch
+	}
+
+	dataset := &models.Dataset{}
+	if err := gob.NewDecoder(&payload).Decode(dataset); err != nil {
+		return nil, fmt.Errorf("error decoding dataset: %w", err)
+	}
+
+	return dataset, nil
+}
+
+// Save writes dataset to a snapshot file at filepath. The snapshot is
+// written to a temporary file beside it and renamed over filepath, so a
+// crash leaves the previous snapshot, never a truncated one.
+func Save(filepath string, dataset *models.Dataset) error {
+	file, err := os.CreateTemp(path.Dir(filepath), path.Base(filepath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating file %s: %w", filepath, err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	buf := bufio.NewWriter(file)
+	if err := Write(buf, dataset); err != nil {
+		return fmt.Errorf("error writing snapshot %s: %w", filepath, err)
+	}
+
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("error writing snapshot %s: %w", filepath, err)
+	}
+
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("error writing snapshot %s: %w", filepath, err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error writing snapshot %s: %w", filepath, err)
+	}
+
+	if err := os.Rename(file.Name(), filepath); err != nil {
+		return fmt.Errorf("error renaming snapshot %s: %w", filepath, err)
+	}
+
+	return nil
+}
+
+// Load reads the snapshot file at filepath.
+func Load(filepath string) (*models.Dataset, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file %s: %w", filepath, err)
+	}
+	defer file.Close()
+
+	dataset, err := Read(bufio.NewReader(file))
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshot %s: %w", filepath, err)
+	}
+
+	return dataset, nil
+}
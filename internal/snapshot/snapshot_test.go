@@ -0,0 +1,117 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/directory"
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/internal/snapshot"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "edne.snapshot")
+	require.NoError(t, snapshot.Save(path, dataset))
+
+	loaded, err := snapshot.Load(path)
+	require.NoError(t, err)
+	require.Equal(t, dataset, loaded)
+	require.Len(t, loaded.Addresses, 446)
+}
+
+// Save replaces a snapshot through a temporary file, which is removed
+// whether or not the snapshot could be put in place.
+func TestSnapshotSaveReplaces(t *testing.T) {
+	dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "edne.snapshot")
+	require.NoError(t, snapshot.Save(path, dataset))
+	require.NoError(t, snapshot.Save(path, dataset))
+
+	loaded, err := snapshot.Load(path)
+	require.NoError(t, err)
+	require.Equal(t, dataset, loaded)
+
+	// A directory in the way fails the rename.
+	blocked := filepath.Join(dir, "blocked")
+	require.NoError(t, os.MkdirAll(filepath.Join(blocked, "keep"), 0o755))
+	require.Error(t, snapshot.Save(blocked, dataset))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
+
+func TestSnapshotRejectsCorruption(t *testing.T) {
+	dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, snapshot.Write(&buf, dataset))
+	encoded := buf.Bytes()
+
+	corrupted := bytes.Clone(encoded)
+	corrupted[len(corrupted)/2] ^= 0xff
+	_, err = snapshot.Read(bytes.NewReader(corrupted))
+	require.ErrorIs(t, err, snapshot.ErrChecksumMismatch)
+
+	_, err = snapshot.Read(bytes.NewReader(encoded[:len(encoded)-1]))
+	require.ErrorIs(t, err, snapshot.ErrInvalidSnapshot)
+
+	_, err = snapshot.Read(bytes.NewReader([]byte("LOG_LOCALIDADE")))
+	require.ErrorIs(t, err, snapshot.ErrInvalidSnapshot)
+
+	// A payload size larger than the snapshot is refused without
+	// allocating it. The size follows the magic and the version.
+	oversized := bytes.Clone(encoded)
+	binary.LittleEndian.PutUint64(oversized[10:], 1<<62)
+	_, err = snapshot.Read(bytes.NewReader(oversized))
+	require.ErrorIs(t, err, snapshot.ErrInvalidSnapshot)
+}
+
+func TestSnapshotRejectsOtherVersions(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, snapshot.Write(&buf, &models.Dataset{}))
+	encoded := buf.Bytes()
+
+	// The version follows the 8 byte magic.
+	binary.LittleEndian.PutUint16(encoded[8:], snapshot.FormatVersion+1)
+
+	_, err := snapshot.Read(bytes.NewReader(encoded))
+	require.ErrorIs(t, err, snapshot.ErrIncompatibleVersion)
+}
+
+// BenchmarkLoad and BenchmarkParse compare a startup from a snapshot with
+// one from the text release, both ending with the indexes built.
+func BenchmarkLoad(b *testing.B) {
+	dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(b, err)
+
+	path := filepath.Join(b.TempDir(), "edne.snapshot")
+	require.NoError(b, snapshot.Save(path, dataset))
+
+	for range b.N {
+		loaded, err := snapshot.Load(path)
+		require.NoError(b, err)
+		directory.New(loaded)
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	for range b.N {
+		dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+		require.NoError(b, err)
+		directory.New(dataset)
+	}
+}
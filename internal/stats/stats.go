@@ -0,0 +1,274 @@
+package stats
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/NSXBet/edne/internal/models"
+)
+
+// Grouping is what the rows of a report are aggregated by.
+type Grouping string
+
+const (
+	GroupState        Grouping = "state"
+	GroupLocality     Grouping = "locality"
+	GroupLocalityType Grouping = "type"
+)
+
+type StatsOption func(opts *StatsOptions)
+
+type StatsOptions struct {
+	LargeUsers       []models.LargeUser
+	OperationalUnits []models.OperationalUnit
+	CPCs             []models.CPC
+}
+
+// WithLargeUsers counts the CEPs of large users, read with
+// parser.ParseLargeUsers, which the dataset does not hold.
+func WithLargeUsers(largeUsers []models.LargeUser) StatsOption {
+	return func(opts *StatsOptions) {
+		opts.LargeUsers = largeUsers
+	}
+}
+
+// WithOperationalUnits counts the CEPs of Correios units.
+func WithOperationalUnits(units []models.OperationalUnit) StatsOption {
+	return func(opts *StatsOptions) {
+		opts.OperationalUnits = units
+	}
+}
+
+// WithCPCs counts the CEPs of community post office boxes.
+func WithCPCs(cpcs []models.CPC) StatsOption {
+	return func(opts *StatsOptions) {
+		opts.CPCs = cpcs
+	}
+}
+
+// Counts are the entities of a group. The CEP counts are split by kind:
+// a CEP belongs to a street, to a locality without street CEPs, to a large
+// user, to a Correios unit or to a CPC.
+type Counts struct {
+	Localities    int
+	Neighborhoods int
+	Streets       int
+	// LocalityZipCodes counts the localities with a single CEP of their
+	// own.
+	LocalityZipCodes int
+	LargeUsers       int
+	OperationalUnits int
+	CPCs             int
+}
+
+// ZipCodes returns the CEPs of every kind.
+func (c Counts) ZipCodes() int {
+	return c.Streets + c.LocalityZipCodes + c.LargeUsers + c.OperationalUnits + c.CPCs
+}
+
+func (c *Counts) add(other Counts) {
+	c.Localities += other.Localities
+	c.Neighborhoods += other.Neighborhoods
+	c.Streets += other.Streets
+	c.LocalityZipCodes += other.LocalityZipCodes
+	c.LargeUsers += other.LargeUsers
+	c.OperationalUnits += other.OperationalUnits
+	c.CPCs += other.CPCs
+}
+
+func (c Counts) sub(other Counts) Counts {
+	return Counts{
+		Localities:       c.Localities - other.Localities,
+		Neighborhoods:    c.Neighborhoods - other.Neighborhoods,
+		Streets:          c.Streets - other.Streets,
+		LocalityZipCodes: c.LocalityZipCodes - other.LocalityZipCodes,
+		LargeUsers:       c.LargeUsers - other.LargeUsers,
+		OperationalUnits: c.OperationalUnits - other.OperationalUnits,
+		CPCs:             c.CPCs - other.CPCs,
+	}
+}
+
+// Row is the counts of one group. Only the fields of the grouping are set:
+// State for GroupState; State, LocalityID, Locality and Type for
+// GroupLocality; and Type for GroupLocalityType.
+type Row struct {
+	State      string
+	LocalityID int
+	Locality   string
+	Type       models.LocationType
+	Counts
+}
+
+type key struct {
+	state      string
+	localityID int
+	typ        models.LocationType
+}
+
+func (r Row) key() key {
+	return key{r.State, r.LocalityID, r.Type}
+}
+
+// Stats aggregates the entities of a dataset.
+type Stats struct {
+	total         Counts
+	states        map[string]*Row
+	localities    map[int]*Row
+	locationTypes map[models.LocationType]*Row
+	locations     map[int]models.Location
+}
+
+// New counts the localities, neighborhoods and CEPs of dataset. Entities
+// whose locality is not in the dataset are counted under their own UF and
+// locality ID, with an empty locality name and type.
+func New(dataset *models.Dataset, opts ...StatsOption) *Stats {
+	options := &StatsOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	s := &Stats{
+		states:        map[string]*Row{},
+		localities:    map[int]*Row{},
+		locationTypes: map[models.LocationType]*Row{},
+		locations:     dataset.Locations,
+	}
+
+	for _, location := range dataset.Locations {
+		counts := Counts{Localities: 1}
+		if location.ZipCode != 0 {
+			counts.LocalityZipCodes = 1
+		}
+		s.add(location.State, location.ID, counts)
+	}
+	for _, neighborhood := range dataset.Neighborhoods {
+		s.add(neighborhood.State, neighborhood.LocationID, Counts{Neighborhoods: 1})
+	}
+	for _, street := range dataset.Streets {
+		s.add(street.State, street.LocationID, Counts{Streets: 1})
+	}
+	for _, largeUser := range options.LargeUsers {
+		s.add(largeUser.State, largeUser.LocationID, Counts{LargeUsers: 1})
+	}
+	for _, unit := range options.OperationalUnits {
+		s.add(unit.State, unit.LocationID, Counts{OperationalUnits: 1})
+	}
+	for _, cpc := range options.CPCs {
+		s.add(cpc.State, cpc.LocationID, Counts{CPCs: 1})
+	}
+
+	return s
+}
+
+func (s *Stats) add(state string, locationID int, counts Counts) {
+	location, ok := s.locations[locationID]
+	if !ok {
+		location = models.Location{ID: locationID, State: state}
+	}
+
+	s.total.add(counts)
+
+	row, ok := s.states[state]
+	if !ok {
+		row = &Row{State: state}
+		s.states[state] = row
+	}
+	row.add(counts)
+
+	row, ok = s.localities[locationID]
+	if !ok {
+		row = &Row{State: location.State, LocalityID: locationID, Locality: location.Name, Type: location.Type}
+		s.localities[locationID] = row
+	}
+	row.add(counts)
+
+	row, ok = s.locationTypes[location.Type]
+	if !ok {
+		row = &Row{Type: location.Type}
+		s.locationTypes[location.Type] = row
+	}
+	row.add(counts)
+}
+
+// Total returns the counts of the whole dataset.
+func (s *Stats) Total() Counts {
+	return s.total
+}
+
+// ByState returns a row per UF, ordered by UF.
+func (s *Stats) ByState() []Row {
+	return sortedRows(s.states)
+}
+
+// ByLocality returns a row per locality, ordered by UF, name and ID.
+// Districts are counted apart from the municipality they belong to.
+func (s *Stats) ByLocality() []Row {
+	return sortedRows(s.localities)
+}
+
+// ByLocalityType returns a row per locality type, ordered by type.
+func (s *Stats) ByLocalityType() []Row {
+	return sortedRows(s.locationTypes)
+}
+
+// Group returns the rows of the given grouping.
+func (s *Stats) Group(by Grouping) ([]Row, error) {
+	switch by {
+	case GroupState:
+		return s.ByState(), nil
+	case GroupLocality:
+		return s.ByLocality(), nil
+	case GroupLocalityType:
+		return s.ByLocalityType(), nil
+	default:
+		return nil, fmt.Errorf("unsupported grouping %q", by)
+	}
+}
+
+// Changes returns how the counts of each group moved from previous to
+// current, both of the same grouping, leaving out groups that did not
+// change. Groups only in one of them are compared against zero.
+func Changes(previous, current []Row) []Row {
+	rows := map[key]*Row{}
+	for _, row := range current {
+		rows[row.key()] = &row
+	}
+	for _, row := range previous {
+		change, ok := rows[row.key()]
+		if !ok {
+			change = &Row{State: row.State, LocalityID: row.LocalityID, Locality: row.Locality, Type: row.Type}
+			rows[row.key()] = change
+		}
+		change.Counts = change.Counts.sub(row.Counts)
+	}
+
+	changes := []Row{}
+	for _, row := range rows {
+		if row.Counts != (Counts{}) {
+			changes = append(changes, *row)
+		}
+	}
+	slices.SortFunc(changes, compareRows)
+
+	return changes
+}
+
+func sortedRows[K comparable](rows map[K]*Row) []Row {
+	sorted := make([]Row, 0, len(rows))
+	for _, row := range rows {
+		sorted = append(sorted, *row)
+	}
+	slices.SortFunc(sorted, compareRows)
+
+	return sorted
+}
+
+func compareRows(a, b Row) int {
+	return cmp.Or(
+		cmp.Compare(a.State, b.State),
+		cmp.Compare(a.Locality, b.Locality),
+		cmp.Compare(a.LocalityID, b.LocalityID),
+		cmp.Compare(a.Type, b.Type),
+	)
+}
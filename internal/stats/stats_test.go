@@ -0,0 +1,117 @@
+package stats_test
+
+import (
+	"testing"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/internal/stats"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func newStats(t *testing.T) (*models.Dataset, *stats.Stats) {
+	t.Helper()
+
+	dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	largeUsers, err := parser.ParseLargeUsers(test.Fixture("base"))
+	require.NoError(t, err)
+
+	units, err := parser.ParseOperationalUnits(test.Fixture("base"))
+	require.NoError(t, err)
+
+	cpcs, err := parser.ParseCPCs(test.Fixture("base"))
+	require.NoError(t, err)
+
+	return dataset, stats.New(dataset,
+		stats.WithLargeUsers(largeUsers),
+		stats.WithOperationalUnits(units),
+		stats.WithCPCs(cpcs),
+	)
+}
+
+func find(rows []stats.Row, match func(stats.Row) bool) stats.Row {
+	for _, row := range rows {
+		if match(row) {
+			return row
+		}
+	}
+	return stats.Row{}
+}
+
+func TestStatsTotal(t *testing.T) {
+	dataset, s := newStats(t)
+
+	total := s.Total()
+	require.Equal(t, len(dataset.Locations), total.Localities)
+	require.Equal(t, len(dataset.Neighborhoods), total.Neighborhoods)
+	require.Equal(t, len(dataset.Streets), total.Streets)
+	require.Equal(t, 12, total.LargeUsers)
+	require.Equal(t, 12, total.CPCs)
+	require.Equal(t, 12, total.OperationalUnits)
+
+	for _, rows := range [][]stats.Row{s.ByState(), s.ByLocality(), s.ByLocalityType()} {
+		var sum stats.Counts
+		for _, row := range rows {
+			sum.Localities += row.Localities
+			sum.Neighborhoods += row.Neighborhoods
+			sum.Streets += row.Streets
+			sum.LocalityZipCodes += row.LocalityZipCodes
+			sum.LargeUsers += row.LargeUsers
+			sum.OperationalUnits += row.OperationalUnits
+			sum.CPCs += row.CPCs
+		}
+		require.Equal(t, total, sum)
+	}
+}
+
+func TestStatsGroups(t *testing.T) {
+	_, s := newStats(t)
+
+	states := s.ByState()
+	require.Equal(t, "AC", states[0].State)
+	require.Equal(t, 212, find(states, func(r stats.Row) bool { return r.State == "SP" }).Streets)
+
+	rioBranco := find(s.ByLocality(), func(r stats.Row) bool { return r.LocalityID == 16 })
+	require.Equal(t, stats.Row{
+		State:      "AC",
+		LocalityID: 16,
+		Locality:   "Rio Branco",
+		Type:       models.LocationTypeCity,
+		Counts:     stats.Counts{Localities: 1, Neighborhoods: 6, Streets: 11, LargeUsers: 7, OperationalUnits: 7},
+	}, rioBranco)
+	require.Equal(t, 25, rioBranco.ZipCodes())
+
+	// Streets of localities left out of the sample keep their UF and ID.
+	unknown := find(s.ByLocality(), func(r stats.Row) bool { return r.LocalityID == 9052 })
+	require.Equal(t, "SP", unknown.State)
+	require.Empty(t, unknown.Locality)
+	require.Equal(t, 39, unknown.Streets)
+
+	types := s.ByLocalityType()
+	require.Equal(t, 21, find(types, func(r stats.Row) bool { return r.Type == models.LocationTypeCity }).Localities)
+
+	rows, err := s.Group(stats.GroupLocalityType)
+	require.NoError(t, err)
+	require.Equal(t, types, rows)
+
+	_, err = s.Group("street")
+	require.Error(t, err)
+}
+
+func TestStatsChanges(t *testing.T) {
+	base, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), "")
+	require.NoError(t, err)
+
+	_, current := newStats(t)
+	previous := stats.New(base)
+
+	changes := stats.Changes(previous.ByState(), current.ByState())
+	require.Equal(t, 12, find(changes, func(r stats.Row) bool { return r.State == "AL" }).CPCs)
+	require.Equal(t, 212, find(changes, func(r stats.Row) bool { return r.State == "SP" }).Streets)
+	require.Empty(t, find(changes, func(r stats.Row) bool { return r.State == "DF" }).State)
+
+	require.Empty(t, stats.Changes(current.ByState(), current.ByState()))
+}
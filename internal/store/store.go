@@ -0,0 +1,244 @@
+package store
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+	"os"
+	"sort"
+	"unsafe"
+
+	"github.com/NSXBet/edne/internal/models"
+)
+
+// Layout of a store file, all integers little endian:
+//
+//	header   magic[8] version:u32 count:u32 stringsSize:u32
+//	records  count * (zipCode:u32 + one u32 string offset per field), sorted by zipCode
+//	strings  (length:u16 bytes)... every distinct value stored once
+const (
+	version      = 1
+	headerSize   = 20
+	recordFields = 7
+	recordSize   = recordFields * 4
+)
+
+var magic = [8]byte{'E', 'D', 'N', 'E', 'S', 'T', 'O', 'R'}
+
+var ErrInvalidStore = errors.New("not an edne address store")
+
+// Write encodes addresses into the store file format.
+func Write(w io.Writer, addresses map[int]models.Address) error {
+	zipCodes := make([]int, 0, len(addresses))
+	for zipCode := range addresses {
+		zipCodes = append(zipCodes, zipCode)
+	}
+	sort.Ints(zipCodes)
+
+	var strs []byte
+	offsets := map[string]uint32{}
+	intern := func(s string) (uint32, error) {
+		if off, ok := offsets[s]; ok {
+			return off, nil
+		}
+		if len(s) > math.MaxUint16 {
+			return 0, fmt.Errorf("value too long: %q", s)
+		}
+		off := uint32(len(strs))
+		strs = binary.LittleEndian.AppendUint16(strs, uint16(len(s)))
+		strs = append(strs, s...)
+		offsets[s] = off
+		return off, nil
+	}
+
+	records := make([]byte, 0, len(zipCodes)*recordSize)
+	for _, zipCode := range zipCodes {
+		a := addresses[zipCode]
+		records = binary.LittleEndian.AppendUint32(records, uint32(zipCode))
+		for _, field := range []string{a.StreetType, a.Street, a.Neighborhood, a.City, a.CityIBGECode, a.State} {
+			off, err := intern(field)
+			if err != nil {
+				return fmt.Errorf("error encoding address %08d: %w", zipCode, err)
+			}
+			records = binary.LittleEndian.AppendUint32(records, off)
+		}
+	}
+
+	header := make([]byte, 0, headerSize)
+	header = append(header, magic[:]...)
+	header = binary.LittleEndian.AppendUint32(header, version)
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(zipCodes)))
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(strs)))
+
+	for _, chunk := range [][]byte{header, records, strs} {
+		if _, err := w.Write(chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Build writes addresses to a store file at filepath.
+func Build(filepath string, addresses map[int]models.Address) error {
+	file, err := os.Create(filepath)
+	if err != nil {
+		return fmt.Errorf("error creating file %s: %w", filepath, err)
+	}
+	defer file.Close()
+
+	buf := bufio.NewWriter(file)
+	if err := Write(buf, addresses); err != nil {
+		return fmt.Errorf("error writing store %s: %w", filepath, err)
+	}
+
+	if err := buf.Flush(); err != nil {
+		return fmt.Errorf("error writing store %s: %w", filepath, err)
+	}
+
+	return file.Close()
+}
+
+// Store is a read-only address store backed by a memory-mapped file. The
+// strings it returns point into the mapping and must not be used after Close.
+type Store struct {
+	records []byte
+	strings []byte
+	count   int
+	release func() error
+}
+
+// Open maps the store file at filepath into memory.
+func Open(filepath string) (*Store, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file %s: %w", filepath, err)
+	}
+	defer file.Close()
+
+	data, release, err := mapFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("error mapping file %s: %w", filepath, err)
+	}
+
+	s, err := newStore(data)
+	if err != nil {
+		_ = release()
+		return nil, fmt.Errorf("error opening store %s: %w", filepath, err)
+	}
+	s.release = release
+
+	return s, nil
+}
+
+func newStore(data []byte) (*Store, error) {
+	if len(data) < headerSize || [8]byte(data[:8]) != magic {
+		return nil, ErrInvalidStore
+	}
+
+	if v := binary.LittleEndian.Uint32(data[8:]); v != version {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidStore, v)
+	}
+
+	count := int(binary.LittleEndian.Uint32(data[12:]))
+	stringsSize := int(binary.LittleEndian.Uint32(data[16:]))
+	if len(data) != headerSize+count*recordSize+stringsSize {
+		return nil, fmt.Errorf("%w: unexpected size", ErrInvalidStore)
+	}
+
+	s := &Store{
+		records: data[headerSize : headerSize+count*recordSize],
+		strings: data[headerSize+count*recordSize:],
+		count:   count,
+	}
+
+	// Check every string reference once so lookups never index out of range.
+	for i := 0; i < count; i++ {
+		record := s.record(i)
+		for f := 1; f < recordFields; f++ {
+			off := int(binary.LittleEndian.Uint32(record[f*4:]))
+			if off+2 > len(s.strings) || off+2+int(binary.LittleEndian.Uint16(s.strings[off:])) > len(s.strings) {
+				return nil, fmt.Errorf("%w: string reference out of range", ErrInvalidStore)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// Close unmaps the file.
+func (s *Store) Close() error {
+	if s.release == nil {
+		return nil
+	}
+
+	release := s.release
+	s.release = nil
+	s.records, s.strings, s.count = nil, nil, 0
+
+	return release()
+}
+
+// Len returns the number of addresses in the store.
+func (s *Store) Len() int {
+	return s.count
+}
+
+// Get returns the address for zipCode.
+func (s *Store) Get(zipCode int) (models.Address, bool) {
+	i := sort.Search(s.count, func(i int) bool {
+		return s.zipCode(i) >= zipCode
+	})
+	if i == s.count || s.zipCode(i) != zipCode {
+		return models.Address{}, false
+	}
+
+	return s.address(i), true
+}
+
+// All iterates over the addresses in CEP order.
+func (s *Store) All() iter.Seq2[int, models.Address] {
+	return func(yield func(int, models.Address) bool) {
+		for i := 0; i < s.count; i++ {
+			if !yield(s.zipCode(i), s.address(i)) {
+				return
+			}
+		}
+	}
+}
+
+func (s *Store) record(i int) []byte {
+	return s.records[i*recordSize : (i+1)*recordSize]
+}
+
+func (s *Store) zipCode(i int) int {
+	return int(binary.LittleEndian.Uint32(s.records[i*recordSize:]))
+}
+
+func (s *Store) address(i int) models.Address {
+	record := s.record(i)
+
+	return models.Address{
+		ZipCode:      int(binary.LittleEndian.Uint32(record)),
+		StreetType:   s.string(record[4:]),
+		Street:       s.string(record[8:]),
+		Neighborhood: s.string(record[12:]),
+		City:         s.string(record[16:]),
+		CityIBGECode: s.string(record[20:]),
+		State:        s.string(record[24:]),
+	}
+}
+
+func (s *Store) string(ref []byte) string {
+	off := binary.LittleEndian.Uint32(ref)
+	n := binary.LittleEndian.Uint16(s.strings[off:])
+	if n == 0 {
+		return ""
+	}
+
+	return unsafe.String(&s.strings[off+2], n)
+}
@@ -0,0 +1,71 @@
+package store_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/internal/store"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	addresses, err := parser.NewMasterParser().Parse(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "addresses.store")
+	require.NoError(t, store.Build(path, addresses))
+
+	s, err := store.Open(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.Equal(t, 446, s.Len())
+
+	for zipCode, expected := range addresses {
+		addr, ok := s.Get(zipCode)
+		require.True(t, ok)
+		require.Equal(t, expected, addr)
+	}
+
+	_, ok := s.Get(99999999)
+	require.False(t, ok)
+
+	previous, seen := 0, 0
+	for zipCode, addr := range s.All() {
+		require.Greater(t, zipCode, previous)
+		require.Equal(t, addresses[zipCode], addr)
+		previous = zipCode
+		seen++
+	}
+	require.Equal(t, 446, seen)
+
+	// Repeated values are stored once, so the file is far smaller than the
+	// sum of every field.
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Less(t, info.Size(), int64(len(addresses)*(28+64)))
+
+	require.NoError(t, s.Close())
+	require.Equal(t, 0, s.Len())
+}
+
+func TestStoreRejectsInvalidFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "addresses.store")
+
+	require.NoError(t, os.WriteFile(path, []byte("LOG_LOCALIDADE"), 0o644))
+	_, err := store.Open(path)
+	require.ErrorIs(t, err, store.ErrInvalidStore)
+
+	addresses, err := parser.NewMasterParser().Parse(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+	require.NoError(t, store.Build(path, addresses))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data[:len(data)-1], 0o644))
+	_, err = store.Open(path)
+	require.ErrorIs(t, err, store.ErrInvalidStore)
+}
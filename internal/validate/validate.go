@@ -0,0 +1,108 @@
+package validate
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/NSXBet/edne/internal/models"
+)
+
+type Entity string
+
+const (
+	EntityLocation     Entity = "locality"
+	EntityNeighborhood Entity = "neighborhood"
+	EntityStreet       Entity = "street"
+	EntityNumberRange  Entity = "number_range"
+)
+
+// Issue is a consistency problem in a dataset, such as a reference to a
+// record the release does not contain.
+type Issue struct {
+	Entity  Entity
+	ID      int
+	Message string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s %d: %s", i.Entity, i.ID, i.Message)
+}
+
+// Dataset checks the references between the entities of a dataset. Issues
+// are sorted by entity and ID.
+func Dataset(dataset *models.Dataset) []Issue {
+	var issues []Issue
+
+	report := func(entity Entity, id int, format string, args ...any) {
+		issues = append(issues, Issue{Entity: entity, ID: id, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for id, location := range dataset.Locations {
+		if location.SubordinateLocationID != 0 {
+			if _, ok := dataset.Locations[location.SubordinateLocationID]; !ok {
+				report(EntityLocation, id, "unknown subordinate locality %d", location.SubordinateLocationID)
+			}
+		}
+		if location.Type == models.LocationTypeCity && location.IBGECode == "" {
+			report(EntityLocation, id, "municipality without IBGE code")
+		}
+	}
+
+	for id, neighborhood := range dataset.Neighborhoods {
+		location, ok := dataset.Locations[neighborhood.LocationID]
+		switch {
+		case !ok:
+			report(EntityNeighborhood, id, "unknown locality %d", neighborhood.LocationID)
+		case location.State != neighborhood.State:
+			report(EntityNeighborhood, id, "state %s differs from locality state %s", neighborhood.State, location.State)
+		}
+	}
+
+	for zipCode, street := range dataset.Streets {
+		if zipCode <= 0 || zipCode > 99999999 {
+			report(EntityStreet, street.ID, "invalid CEP %d", zipCode)
+		}
+
+		location, ok := dataset.Locations[street.LocationID]
+		switch {
+		case !ok:
+			report(EntityStreet, street.ID, "unknown locality %d", street.LocationID)
+		case location.State != street.State:
+			report(EntityStreet, street.ID, "state %s differs from locality state %s", street.State, location.State)
+		}
+
+		for _, n := range []*models.Neighborhood{street.StartingNeighborhood, street.EndingNeighborhood} {
+			if n == nil || n.ID == 0 {
+				continue
+			}
+			if _, ok := dataset.Neighborhoods[n.ID]; !ok {
+				report(EntityStreet, street.ID, "unknown neighborhood %d", n.ID)
+			}
+		}
+	}
+
+	streetIDs := make(map[int]bool, len(dataset.Streets))
+	for _, street := range dataset.Streets {
+		streetIDs[street.ID] = true
+	}
+
+	for id, r := range dataset.NumberRanges {
+		if !streetIDs[r.StreetID] {
+			report(EntityNumberRange, id, "unknown street %d", r.StreetID)
+		}
+		if r.End != 0 && r.Start > r.End {
+			report(EntityNumberRange, id, "start %d after end %d", r.Start, r.End)
+		}
+	}
+
+	slices.SortFunc(issues, func(a, b Issue) int {
+		return cmp.Or(
+			cmp.Compare(a.Entity, b.Entity),
+			cmp.Compare(a.ID, b.ID),
+			cmp.Compare(a.Message, b.Message),
+		)
+	})
+
+	return issues
+}
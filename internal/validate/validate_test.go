@@ -0,0 +1,59 @@
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/internal/validate"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataset(t *testing.T) {
+	dataset := &models.Dataset{
+		Locations: map[int]models.Location{
+			1: {ID: 1, State: "ES", Name: "Vitória", Type: models.LocationTypeCity, IBGECode: "3205309"},
+			2: {ID: 2, State: "ES", Name: "Goiabeiras", Type: models.LocationTypeDistrict, SubordinateLocationID: 9},
+		},
+		Neighborhoods: map[int]models.Neighborhood{
+			10: {ID: 10, State: "ES", LocationID: 1, Name: "Centro"},
+			11: {ID: 11, State: "RJ", LocationID: 1, Name: "Praia"},
+		},
+		Streets: map[int]models.Street{
+			29000000: {ID: 100, ZipCode: 29000000, State: "ES", LocationID: 1, StartingNeighborhood: &models.Neighborhood{ID: 10}},
+			29000001: {ID: 101, ZipCode: 29000001, State: "ES", LocationID: 3, StartingNeighborhood: &models.Neighborhood{ID: 12}},
+		},
+		NumberRanges: map[int]models.NumberRange{
+			100: {StreetID: 100, Start: 1, End: 99},
+			102: {StreetID: 102, Start: 50, End: 10},
+		},
+	}
+
+	var got []string
+	for _, issue := range validate.Dataset(dataset) {
+		got = append(got, issue.String())
+	}
+
+	require.Equal(t, []string{
+		"locality 2: unknown subordinate locality 9",
+		"neighborhood 11: state RJ differs from locality state ES",
+		"number_range 102: start 50 after end 10",
+		"number_range 102: unknown street 102",
+		"street 101: unknown locality 3",
+		"street 101: unknown neighborhood 12",
+	}, got)
+}
+
+func TestDatasetFixtures(t *testing.T) {
+	dataset, err := parser.NewMasterParser().ParseDataset(test.Fixture("base"), test.Fixture("update"))
+	require.NoError(t, err)
+
+	// The fixtures are a sample, so most streets point at localities and
+	// neighborhoods that were left out.
+	issues := validate.Dataset(dataset)
+	require.NotEmpty(t, issues)
+	for _, issue := range issues {
+		require.NotEmpty(t, issue.Message)
+	}
+}
@@ -0,0 +1,86 @@
+package writer
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/NSXBet/edne/internal/models"
+)
+
+// Delta writes DELTA_LOG_LOCALIDADE.TXT, DELTA_LOG_BAIRRO.TXT,
+// DELTA_LOG_LOGRADOURO.TXT and DELTA_LOG_NUM_SEC.TXT to dir, in the layout
+// the parsers read as an update.
+func Delta(dir string, delta *models.Delta) error {
+	return writeFiles(dir, []file{
+		{"DELTA_LOG_LOCALIDADE.TXT", func(w io.Writer) error { return DeltaLocations(w, delta.Locations) }},
+		{"DELTA_LOG_BAIRRO.TXT", func(w io.Writer) error { return DeltaNeighborhoods(w, delta.Neighborhoods) }},
+		{"DELTA_LOG_LOGRADOURO.TXT", func(w io.Writer) error { return DeltaStreets(w, delta.Streets) }},
+		{"DELTA_LOG_NUM_SEC.TXT", func(w io.Writer) error { return DeltaNumberRanges(w, delta.NumberRanges) }},
+	})
+}
+
+func DeltaLocations(w io.Writer, changes []models.LocationChange) error {
+	rw := newRecordWriter(w)
+
+	for _, change := range changes {
+		fields := append(locationFields(change.Location), string(change.Operation),
+			previousZipCode(change.Operation, change.Location.ZipCode, change.PreviousZipCode))
+		if err := rw.write(fields...); err != nil {
+			return fmt.Errorf("error writing locality %d: %w", change.Location.ID, err)
+		}
+	}
+
+	return rw.Close()
+}
+
+func DeltaNeighborhoods(w io.Writer, changes []models.NeighborhoodChange) error {
+	rw := newRecordWriter(w)
+
+	for _, change := range changes {
+		fields := append(neighborhoodFields(change.Neighborhood), string(change.Operation))
+		if err := rw.write(fields...); err != nil {
+			return fmt.Errorf("error writing neighborhood %d: %w", change.Neighborhood.ID, err)
+		}
+	}
+
+	return rw.Close()
+}
+
+func DeltaStreets(w io.Writer, changes []models.StreetChange) error {
+	rw := newRecordWriter(w)
+
+	for _, change := range changes {
+		fields := append(streetFields(change.Street), string(change.Operation),
+			previousZipCode(change.Operation, change.Street.ZipCode, change.PreviousZipCode))
+		if err := rw.write(fields...); err != nil {
+			return fmt.Errorf("error writing street %d: %w", change.Street.ID, err)
+		}
+	}
+
+	return rw.Close()
+}
+
+func DeltaNumberRanges(w io.Writer, changes []models.NumberRangeChange) error {
+	rw := newRecordWriter(w)
+
+	for _, change := range changes {
+		fields := append(numberRangeFields(change.NumberRange), string(change.Operation))
+		if err := rw.write(fields...); err != nil {
+			return fmt.Errorf("error writing number range of street %d: %w", change.NumberRange.StreetID, err)
+		}
+	}
+
+	return rw.Close()
+}
+
+// previousZipCode fills CEP_ANT. Correios sets it on every update, to the
+// CEP before the change, and leaves it blank on inserts and deletes.
+func previousZipCode(op models.Operation, zipCode, previous int) string {
+	if op != models.OperationUpdate {
+		return optionalZipCode(previous)
+	}
+	if previous == 0 {
+		previous = zipCode
+	}
+	return optionalZipCode(previous)
+}
@@ -0,0 +1,239 @@
+package writer
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/NSXBet/edne/internal/models"
+)
+
+// Dataset writes LOG_LOCALIDADE.TXT, LOG_BAIRRO.TXT, one
+// LOG_LOGRADOURO_<UF>.TXT per state and LOG_NUM_SEC.TXT to dir, in the
+// layout the parsers read as a base release. Records are ordered by ID.
+func Dataset(dir string, dataset *models.Dataset) error {
+	streetsByState := map[string][]models.Street{}
+	for _, street := range sortedBy(dataset.Streets, func(s models.Street) int { return s.ID }) {
+		streetsByState[street.State] = append(streetsByState[street.State], street)
+	}
+
+	files := []file{
+		{"LOG_LOCALIDADE.TXT", func(w io.Writer) error {
+			return Locations(w, sortedBy(dataset.Locations, func(l models.Location) int { return l.ID }))
+		}},
+		{"LOG_BAIRRO.TXT", func(w io.Writer) error {
+			return Neighborhoods(w, sortedBy(dataset.Neighborhoods, func(n models.Neighborhood) int { return n.ID }))
+		}},
+		{"LOG_NUM_SEC.TXT", func(w io.Writer) error {
+			return NumberRanges(w, sortedBy(dataset.NumberRanges, func(r models.NumberRange) int { return r.StreetID }))
+		}},
+	}
+
+	for _, state := range slices.Sorted(maps.Keys(streetsByState)) {
+		streets := streetsByState[state]
+		files = append(files, file{"LOG_LOGRADOURO_" + state + ".TXT", func(w io.Writer) error {
+			return Streets(w, streets)
+		}})
+	}
+
+	return writeFiles(dir, files)
+}
+
+// Locations writes LOG_LOCALIDADE records.
+func Locations(w io.Writer, locations []models.Location) error {
+	return writeRecords(w, locations, locationFields, func(l models.Location) string {
+		return fmt.Sprintf("locality %d", l.ID)
+	})
+}
+
+// Neighborhoods writes LOG_BAIRRO records.
+func Neighborhoods(w io.Writer, neighborhoods []models.Neighborhood) error {
+	return writeRecords(w, neighborhoods, neighborhoodFields, func(n models.Neighborhood) string {
+		return fmt.Sprintf("neighborhood %d", n.ID)
+	})
+}
+
+// Streets writes LOG_LOGRADOURO_<UF> records.
+func Streets(w io.Writer, streets []models.Street) error {
+	return writeRecords(w, streets, streetFields, func(s models.Street) string {
+		return fmt.Sprintf("street %d", s.ID)
+	})
+}
+
+// NumberRanges writes LOG_NUM_SEC records.
+func NumberRanges(w io.Writer, ranges []models.NumberRange) error {
+	return writeRecords(w, ranges, numberRangeFields, func(r models.NumberRange) string {
+		return fmt.Sprintf("number range of street %d", r.StreetID)
+	})
+}
+
+// LargeUsers writes LOG_GRANDE_USUARIO records.
+func LargeUsers(w io.Writer, users []models.LargeUser) error {
+	return writeRecords(w, users, func(u models.LargeUser) []string {
+		return []string{
+			strconv.Itoa(u.ID),
+			u.State,
+			strconv.Itoa(u.LocationID),
+			optionalID(u.NeighborhoodID),
+			optionalID(u.StreetID),
+			u.Name,
+			u.Address,
+			models.FormatZipCode(u.ZipCode),
+			u.Abbreviation,
+		}
+	}, func(u models.LargeUser) string {
+		return fmt.Sprintf("large user %d", u.ID)
+	})
+}
+
+// OperationalUnits writes LOG_UNID_OPER records.
+func OperationalUnits(w io.Writer, units []models.OperationalUnit) error {
+	return writeRecords(w, units, func(u models.OperationalUnit) []string {
+		return []string{
+			strconv.Itoa(u.ID),
+			u.State,
+			strconv.Itoa(u.LocationID),
+			optionalID(u.NeighborhoodID),
+			optionalID(u.StreetID),
+			u.Name,
+			u.Address,
+			models.FormatZipCode(u.ZipCode),
+			flag(u.PostOfficeBoxes),
+			u.Abbreviation,
+		}
+	}, func(u models.OperationalUnit) string {
+		return fmt.Sprintf("operational unit %d", u.ID)
+	})
+}
+
+// CPCs writes LOG_CPC records.
+func CPCs(w io.Writer, cpcs []models.CPC) error {
+	return writeRecords(w, cpcs, func(c models.CPC) []string {
+		return []string{
+			strconv.Itoa(c.ID),
+			c.State,
+			strconv.Itoa(c.LocationID),
+			c.Name,
+			c.Address,
+			models.FormatZipCode(c.ZipCode),
+		}
+	}, func(c models.CPC) string {
+		return fmt.Sprintf("CPC %d", c.ID)
+	})
+}
+
+// StateRanges writes LOG_FAIXA_UF records.
+func StateRanges(w io.Writer, ranges []models.StateRange) error {
+	return writeRecords(w, ranges, func(r models.StateRange) []string {
+		return []string{r.State, models.FormatZipCode(r.Start), models.FormatZipCode(r.End)}
+	}, func(r models.StateRange) string {
+		return "range of " + r.State
+	})
+}
+
+// LocationRanges writes LOG_FAIXA_LOCALIDADE records.
+func LocationRanges(w io.Writer, ranges []models.LocationRange) error {
+	return writeRecords(w, ranges, func(r models.LocationRange) []string {
+		return []string{
+			strconv.Itoa(r.LocationID),
+			models.FormatZipCode(r.Start),
+			models.FormatZipCode(r.End),
+			string(r.Type),
+		}
+	}, func(r models.LocationRange) string {
+		return fmt.Sprintf("range of locality %d", r.LocationID)
+	})
+}
+
+// NeighborhoodRanges writes LOG_FAIXA_BAIRRO records.
+func NeighborhoodRanges(w io.Writer, ranges []models.NeighborhoodRange) error {
+	return writeRecords(w, ranges, func(r models.NeighborhoodRange) []string {
+		return []string{strconv.Itoa(r.NeighborhoodID), models.FormatZipCode(r.Start), models.FormatZipCode(r.End)}
+	}, func(r models.NeighborhoodRange) string {
+		return fmt.Sprintf("range of neighborhood %d", r.NeighborhoodID)
+	})
+}
+
+// CPCRanges writes LOG_FAIXA_CPC records.
+func CPCRanges(w io.Writer, ranges []models.CPCRange) error {
+	return writeRecords(w, ranges, func(r models.CPCRange) []string {
+		return []string{strconv.Itoa(r.CPCID), strconv.Itoa(r.Start), strconv.Itoa(r.End)}
+	}, func(r models.CPCRange) string {
+		return fmt.Sprintf("range of CPC %d", r.CPCID)
+	})
+}
+
+// OperationalUnitRanges writes LOG_FAIXA_UOP records.
+func OperationalUnitRanges(w io.Writer, ranges []models.OperationalUnitRange) error {
+	return writeRecords(w, ranges, func(r models.OperationalUnitRange) []string {
+		return []string{strconv.Itoa(r.OperationalUnitID), strconv.Itoa(r.Start), strconv.Itoa(r.End)}
+	}, func(r models.OperationalUnitRange) string {
+		return fmt.Sprintf("range of operational unit %d", r.OperationalUnitID)
+	})
+}
+
+// LocationVariations writes LOG_VAR_LOC records.
+func LocationVariations(w io.Writer, variations []models.LocationVariation) error {
+	return writeRecords(w, variations, func(v models.LocationVariation) []string {
+		return []string{strconv.Itoa(v.LocationID), strconv.Itoa(v.Order), v.Name}
+	}, func(v models.LocationVariation) string {
+		return fmt.Sprintf("variation %d of locality %d", v.Order, v.LocationID)
+	})
+}
+
+// NeighborhoodVariations writes LOG_VAR_BAI records.
+func NeighborhoodVariations(w io.Writer, variations []models.NeighborhoodVariation) error {
+	return writeRecords(w, variations, func(v models.NeighborhoodVariation) []string {
+		return []string{strconv.Itoa(v.NeighborhoodID), strconv.Itoa(v.Order), v.Name}
+	}, func(v models.NeighborhoodVariation) string {
+		return fmt.Sprintf("variation %d of neighborhood %d", v.Order, v.NeighborhoodID)
+	})
+}
+
+// StreetVariations writes LOG_VAR_LOG records.
+func StreetVariations(w io.Writer, variations []models.StreetVariation) error {
+	return writeRecords(w, variations, func(v models.StreetVariation) []string {
+		return []string{strconv.Itoa(v.StreetID), strconv.Itoa(v.Order), v.Type, v.Name}
+	}, func(v models.StreetVariation) string {
+		return fmt.Sprintf("variation %d of street %d", v.Order, v.StreetID)
+	})
+}
+
+// Countries writes ECT_PAIS records.
+func Countries(w io.Writer, countries []models.Country) error {
+	return writeRecords(w, countries, func(c models.Country) []string {
+		return []string{c.Code, c.AlternativeCode, c.Name, c.EnglishName, c.FrenchName, c.Abbreviation}
+	}, func(c models.Country) string {
+		return "country " + c.Code
+	})
+}
+
+func writeRecords[T any](w io.Writer, records []T, fields func(T) []string, describe func(T) string) error {
+	rw := newRecordWriter(w)
+
+	for _, record := range records {
+		if err := rw.write(fields(record)...); err != nil {
+			return fmt.Errorf("error writing %s: %w", describe(record), err)
+		}
+	}
+
+	return rw.Close()
+}
+
+func sortedBy[T any](m map[int]T, key func(T) int) []T {
+	values := slices.Collect(maps.Values(m))
+	slices.SortFunc(values, func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	})
+	return values
+}
+
+func flag(b bool) string {
+	if b {
+		return "S"
+	}
+	return "N"
+}
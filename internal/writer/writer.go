@@ -0,0 +1,156 @@
+package writer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/NSXBet/edne/internal/models"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/transform"
+)
+
+const (
+	delimiter  = "@"
+	lineEnding = "\r\n"
+)
+
+// recordWriter writes '@'-delimited, Windows-1252 records with CRLF line
+// endings, as Correios distributes the eDNE.
+type recordWriter struct {
+	w io.WriteCloser
+}
+
+func newRecordWriter(w io.Writer) *recordWriter {
+	return &recordWriter{w: transform.NewWriter(w, charmap.Windows1252.NewEncoder())}
+}
+
+func (rw *recordWriter) write(fields ...string) error {
+	for _, field := range fields {
+		if strings.ContainsAny(field, delimiter+"\r\n") {
+			return fmt.Errorf("field %q contains a delimiter or line break", field)
+		}
+	}
+
+	if _, err := io.WriteString(rw.w, strings.Join(fields, delimiter)+lineEnding); err != nil {
+		return fmt.Errorf("error writing record %v: %w", fields, err)
+	}
+
+	return nil
+}
+
+func (rw *recordWriter) Close() error {
+	return rw.w.Close()
+}
+
+// file is an eDNE file to be written by writeFiles.
+type file struct {
+	name  string
+	write func(w io.Writer) error
+}
+
+func writeFiles(dir string, files []file) error {
+	for _, f := range files {
+		if err := writeFile(filepath.Join(dir, f.name), f.write); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFile(filepath string, write func(w io.Writer) error) error {
+	file, err := os.Create(filepath)
+	if err != nil {
+		return fmt.Errorf("error creating file %s: %w", filepath, err)
+	}
+
+	if err := write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("error writing file %s: %w", filepath, err)
+	}
+
+	return file.Close()
+}
+
+func locationFields(l models.Location) []string {
+	return []string{
+		strconv.Itoa(l.ID),
+		l.State,
+		l.Name,
+		optionalZipCode(l.ZipCode),
+		strconv.Itoa(int(l.Situation)),
+		string(l.Type),
+		optionalID(l.SubordinateLocationID),
+		l.Abbreviation,
+		l.IBGECode,
+	}
+}
+
+func neighborhoodFields(n models.Neighborhood) []string {
+	return []string{
+		strconv.Itoa(n.ID),
+		n.State,
+		strconv.Itoa(n.LocationID),
+		n.Name,
+		n.Abbreviation,
+	}
+}
+
+func streetFields(s models.Street) []string {
+	return []string{
+		strconv.Itoa(s.ID),
+		s.State,
+		strconv.Itoa(s.LocationID),
+		neighborhoodID(s.StartingNeighborhood),
+		neighborhoodID(s.EndingNeighborhood),
+		s.Name,
+		s.Complement,
+		models.FormatZipCode(s.ZipCode),
+		s.Type,
+		flag(s.UseType),
+		s.Abbreviation,
+	}
+}
+
+func numberRangeFields(r models.NumberRange) []string {
+	return []string{
+		strconv.Itoa(r.StreetID),
+		sectionNumber(r.Start, r.Kilometres),
+		sectionNumber(r.End, r.Kilometres),
+		string(r.Side),
+	}
+}
+
+// sectionNumber writes kilometres with the three decimals of the Correios
+// files.
+func sectionNumber(n int, kilometres bool) string {
+	if !kilometres {
+		return strconv.Itoa(n)
+	}
+	return fmt.Sprintf("%d,%03d", n/1000, n%1000)
+}
+
+func optionalZipCode(zipCode int) string {
+	if zipCode == 0 {
+		return ""
+	}
+	return models.FormatZipCode(zipCode)
+}
+
+func optionalID(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
+
+func neighborhoodID(n *models.Neighborhood) string {
+	if n == nil {
+		return ""
+	}
+	return optionalID(n.ID)
+}
@@ -0,0 +1,175 @@
+package writer_test
+
+import (
+	"bytes"
+	"cmp"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/NSXBet/edne/internal/models"
+	"github.com/NSXBet/edne/internal/parser"
+	"github.com/NSXBet/edne/internal/writer"
+	"github.com/NSXBet/edne/test"
+	"github.com/stretchr/testify/require"
+)
+
+// fixture reads a base fixture. The samples were cut from the full files and
+// most lost the line break after their last record, which the writers
+// always emit.
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join(test.Fixture("base"), name))
+	require.NoError(t, err)
+
+	if !bytes.HasSuffix(data, []byte("\r\n")) {
+		data = append(data, "\r\n"...)
+	}
+
+	return data
+}
+
+// inIDOrder puts the records of a sample in the ID order Dataset writes them
+// in, as the samples are not ordered by ID. The records themselves are kept
+// byte for byte.
+func inIDOrder(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	lines := bytes.SplitAfter(data, []byte("\r\n"))
+	lines = lines[:len(lines)-1]
+	slices.SortStableFunc(lines, func(a, b []byte) int {
+		return cmp.Compare(recordID(t, a), recordID(t, b))
+	})
+
+	return bytes.Join(lines, nil)
+}
+
+func recordID(t *testing.T, line []byte) int {
+	t.Helper()
+
+	field, _, _ := bytes.Cut(line, []byte("@"))
+	id, err := strconv.Atoi(string(field))
+	require.NoError(t, err)
+
+	return id
+}
+
+func TestDatasetRoundTrip(t *testing.T) {
+	masterParser := parser.NewMasterParser()
+
+	dataset, err := masterParser.ParseDataset(test.Fixture("base"), "")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, writer.Dataset(dir, dataset))
+
+	for _, name := range []string{
+		"LOG_LOCALIDADE.TXT",
+		"LOG_BAIRRO.TXT",
+		"LOG_LOGRADOURO_AC.TXT",
+		"LOG_LOGRADOURO_DF.TXT",
+		"LOG_LOGRADOURO_ES.TXT",
+		"LOG_NUM_SEC.TXT",
+	} {
+		written, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err, name)
+		require.Equal(t, string(inIDOrder(t, fixture(t, name))), string(written), name)
+	}
+
+	reparsed, err := masterParser.ParseDataset(dir, "")
+	require.NoError(t, err)
+
+	dataset.Release, reparsed.Release = nil, nil
+	require.Equal(t, dataset, reparsed)
+}
+
+func TestLayoutsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(w io.Writer, dir string) error
+	}{
+		{"ECT_PAIS.TXT", roundTrip(parser.ParseCountries, writer.Countries)},
+		{"LOG_CPC.TXT", roundTrip(parser.ParseCPCs, writer.CPCs)},
+		{"LOG_FAIXA_BAIRRO.TXT", roundTrip(parser.ParseNeighborhoodRanges, writer.NeighborhoodRanges)},
+		{"LOG_FAIXA_CPC.TXT", roundTrip(parser.ParseCPCRanges, writer.CPCRanges)},
+		{"LOG_FAIXA_LOCALIDADE.TXT", roundTrip(parser.ParseLocationRanges, writer.LocationRanges)},
+		{"LOG_FAIXA_UF.TXT", roundTrip(parser.ParseStateRanges, writer.StateRanges)},
+		{"LOG_FAIXA_UOP.TXT", roundTrip(parser.ParseOperationalUnitRanges, writer.OperationalUnitRanges)},
+		{"LOG_GRANDE_USUARIO.TXT", roundTrip(parser.ParseLargeUsers, writer.LargeUsers)},
+		{"LOG_VAR_BAI.TXT", roundTrip(parser.ParseNeighborhoodVariations, writer.NeighborhoodVariations)},
+		{"LOG_VAR_LOC.TXT", roundTrip(parser.ParseLocationVariations, writer.LocationVariations)},
+		{"LOG_VAR_LOG.TXT", roundTrip(parser.ParseStreetVariations, writer.StreetVariations)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, tt.write(&buf, test.Fixture("base")))
+			require.Equal(t, fixture(t, tt.name), buf.Bytes())
+		})
+	}
+}
+
+// The LOG_UNID_OPER sample is a copy of LOG_GRANDE_USUARIO and lacks
+// UOP_IN_CP, so the layout is checked against a record of its own.
+func TestOperationalUnitsRoundTrip(t *testing.T) {
+	units := []models.OperationalUnit{{
+		ID:              1763,
+		State:           "SP",
+		LocationID:      9668,
+		NeighborhoodID:  18407,
+		StreetID:        271540,
+		Name:            "AC São Bento",
+		Address:         "Rua São Bento, 321",
+		ZipCode:         1010970,
+		PostOfficeBoxes: true,
+		Abbreviation:    "AC S Bento",
+	}}
+
+	dir := t.TempDir()
+	file, err := os.Create(filepath.Join(dir, "LOG_UNID_OPER.TXT"))
+	require.NoError(t, err)
+	require.NoError(t, writer.OperationalUnits(file, units))
+	require.NoError(t, file.Close())
+
+	data, err := os.ReadFile(file.Name())
+	require.NoError(t, err)
+	require.Equal(t, "1763@SP@9668@18407@271540@AC S\xe3o Bento@Rua S\xe3o Bento, 321@01010970@S@AC S Bento\r\n", string(data))
+
+	parsed, err := parser.ParseOperationalUnits(dir)
+	require.NoError(t, err)
+	require.Equal(t, units, parsed)
+}
+
+func TestNumberRangesKilometres(t *testing.T) {
+	records := "953942@90,000@91,005@A\r\n287611@1@750@A\r\n"
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "LOG_NUM_SEC.TXT"), []byte(records), 0o644))
+
+	ranges, err := parser.NewNumberRangeParser().Parse(dir, "")
+	require.NoError(t, err)
+	require.Equal(t, 91005, ranges[953942].End)
+
+	var buf bytes.Buffer
+	require.NoError(t, writer.NumberRanges(&buf, []models.NumberRange{ranges[953942], ranges[287611]}))
+	require.Equal(t, records, buf.String())
+}
+
+func TestWriterRejectsDelimiters(t *testing.T) {
+	err := writer.Neighborhoods(io.Discard, []models.Neighborhood{{ID: 1, State: "SP", LocationID: 1, Name: "A@B"}})
+	require.ErrorContains(t, err, "neighborhood 1")
+}
+
+func roundTrip[T any](parse func(dir string) ([]T, error), write func(w io.Writer, records []T) error) func(io.Writer, string) error {
+	return func(w io.Writer, dir string) error {
+		records, err := parse(dir)
+		if err != nil {
+			return err
+		}
+		return write(w, records)
+	}
+}
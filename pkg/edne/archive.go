@@ -0,0 +1,11 @@
+package edne
+
+import "github.com/NSXBet/edne/internal/archive"
+
+var ErrNoData = archive.ErrNoData
+
+// OpenArchive returns the directory holding the eDNE files under path, a
+// directory or a Correios zip. Call closeFn once the files were parsed.
+func OpenArchive(path string) (dir string, closeFn func() error, err error) {
+	return archive.Open(path)
+}
@@ -0,0 +1,39 @@
+package edne
+
+import "github.com/NSXBet/edne/internal/compat"
+
+type (
+	ViaCEP         = compat.ViaCEP
+	ViaCEPError    = compat.ViaCEPError
+	ViaCEPOption   = compat.ViaCEPOption
+	BrasilAPI      = compat.BrasilAPI
+	BrasilAPIError = compat.BrasilAPIError
+)
+
+var WithComplement = compat.WithComplement
+
+// NewViaCEP converts an address to the JSON/XML shape of viacep.com.br.
+func NewViaCEP(address Address, opts ...ViaCEPOption) ViaCEP {
+	return compat.NewViaCEP(address, opts...)
+}
+
+// NewViaCEPError returns ViaCEP's {"erro": true} answer for unknown CEPs.
+func NewViaCEPError() ViaCEPError {
+	return compat.NewViaCEPError()
+}
+
+// NewBrasilAPI converts an address to the shape of BrasilAPI's CEP v1.
+func NewBrasilAPI(address Address) BrasilAPI {
+	return compat.NewBrasilAPI(address)
+}
+
+// NewBrasilAPIError returns BrasilAPI's not-found body.
+func NewBrasilAPIError() BrasilAPIError {
+	return compat.NewBrasilAPIError()
+}
+
+var (
+	WriteViaCEPJSON    = compat.WriteViaCEPJSON
+	WriteViaCEPXML     = compat.WriteViaCEPXML
+	WriteBrasilAPIJSON = compat.WriteBrasilAPIJSON
+)
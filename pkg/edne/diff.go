@@ -0,0 +1,47 @@
+package edne
+
+import (
+	"io"
+
+	"github.com/NSXBet/edne/internal/diff"
+)
+
+type (
+	ChangeSet   = diff.ChangeSet
+	ChangeKind  = diff.Kind
+	FieldChange = diff.FieldChange
+	ChangeCount = diff.Counts
+	DiffSummary = diff.Summary
+)
+
+const (
+	ChangeAdded    = diff.KindAdded
+	ChangeRemoved  = diff.KindRemoved
+	ChangeModified = diff.KindModified
+)
+
+// Diff compares two parsed releases.
+func Diff(before, after *Dataset) *ChangeSet {
+	return diff.Datasets(before, after)
+}
+
+// DiffReleases parses and compares two base directories.
+func DiffReleases(before, after string) (*ChangeSet, error) {
+	return diff.Releases(before, after)
+}
+
+// WriteChangeSetJSON writes a change set as indented JSON.
+func WriteChangeSetJSON(w io.Writer, cs *ChangeSet) error {
+	return diff.WriteJSON(w, cs)
+}
+
+// WriteChangeSetDelta writes a change set as Correios DELTA_ files to dir.
+func WriteChangeSetDelta(dir string, cs *ChangeSet) error {
+	return diff.WriteDelta(dir, cs)
+}
+
+// WriteCorrections writes the changes made to an edited copy of a release as
+// DELTA_ files to dir, ready to be applied with ParseDataset(base, dir).
+func WriteCorrections(dir string, original, edited *Dataset) error {
+	return diff.WriteDelta(dir, diff.Datasets(original, edited))
+}
@@ -0,0 +1,37 @@
+package edne
+
+import "github.com/NSXBet/edne/internal/directory"
+
+// Directory is the queryable result of Parser.Parse.
+type Directory = directory.Directory
+
+type (
+	Page          = directory.Page
+	PageOption    = directory.PageOption
+	PageOptions   = directory.PageOptions
+	ReverseQuery  = directory.ReverseQuery
+	ReverseResult = directory.ReverseResult
+	ReverseReason = directory.Reason
+	ParsedAddress = directory.ParsedAddress
+)
+
+const (
+	ReasonUnique           = directory.ReasonUnique
+	ReasonNumberInRange    = directory.ReasonNumberInRange
+	ReasonNumberRequired   = directory.ReasonNumberRequired
+	ReasonNumberOutOfRange = directory.ReasonNumberOutOfRange
+	ReasonAmbiguous        = directory.ReasonAmbiguous
+	ReasonNotFound         = directory.ReasonNotFound
+)
+
+var ErrInvalidPrefix = directory.ErrInvalidPrefix
+
+var (
+	WithPageSize = directory.WithPageSize
+	WithCursor   = directory.WithCursor
+)
+
+// NewDirectory indexes a dataset, such as one read by LoadSnapshot.
+func NewDirectory(dataset *Dataset) *Directory {
+	return directory.New(dataset)
+}
@@ -0,0 +1,61 @@
+package edne
+
+import (
+	"io"
+
+	"github.com/NSXBet/edne/internal/export"
+)
+
+type (
+	ExportFormat  = export.Format
+	ExportOption  = export.ExportOption
+	ExportOptions = export.ExportOptions
+	ScoredAddress = export.ScoredAddress
+)
+
+const (
+	ExportFormatJSONLines = export.FormatJSONLines
+	ExportFormatCSV       = export.FormatCSV
+)
+
+var (
+	WithEntities      = export.WithEntities
+	WithByteOrderMark = export.WithByteOrderMark
+)
+
+// ExportAddresses writes addresses as JSON Lines or CSV, ordered by CEP.
+func ExportAddresses(w io.Writer, format ExportFormat, addresses map[int]Address, opts ...ExportOption) error {
+	return export.Addresses(w, format, addresses, opts...)
+}
+
+// ExportScoredAddresses writes search results in their order, each with its
+// score.
+func ExportScoredAddresses(w io.Writer, format ExportFormat, addresses []ScoredAddress, opts ...ExportOption) error {
+	return export.ScoredAddresses(w, format, addresses, opts...)
+}
+
+// ExportDataset writes the dataset files into dir, one file per entity.
+func ExportDataset(dir string, format ExportFormat, dataset *Dataset, opts ...ExportOption) error {
+	return export.Dataset(dir, format, dataset, opts...)
+}
+
+// ExportSQLite writes the dataset into a normalized SQLite database file.
+func ExportSQLite(filepath string, dataset *Dataset) error {
+	return export.SQLite(filepath, dataset)
+}
+
+// ExportPostgres writes a psql script creating the schema and loading the
+// dataset with COPY.
+func ExportPostgres(w io.Writer, dataset *Dataset) error {
+	return export.Postgres(w, dataset)
+}
+
+// ExportPostgresSchema writes the PostgreSQL DDL only.
+func ExportPostgresSchema(w io.Writer) error {
+	return export.PostgresSchema(w)
+}
+
+// ExportPostgresDelta writes the INSERT/UPDATE/DELETE statements applying delta.
+func ExportPostgresDelta(w io.Writer, delta *Delta) error {
+	return export.PostgresDelta(w, delta)
+}
@@ -0,0 +1,12 @@
+package edne
+
+import "github.com/NSXBet/edne/internal/grpcserver"
+
+// GRPCServer implements ednepb.LookupServiceServer over a Directory.
+type GRPCServer = grpcserver.Server
+
+// NewGRPCServer returns a server that answers UNAVAILABLE until
+// SetDirectory is called. Register it with ednepb.RegisterLookupServiceServer.
+func NewGRPCServer() *GRPCServer {
+	return grpcserver.New()
+}
@@ -1,6 +1,16 @@
 package edne
 
-import "github.com/NSXBet/edne/internal/parser"
+import (
+	"github.com/NSXBet/edne/internal/directory"
+	"github.com/NSXBet/edne/internal/parser"
+)
+
+type Format = parser.Format
+
+const (
+	FormatDelimited  = parser.FormatDelimited
+	FormatFixedWidth = parser.FormatFixedWidth
+)
 
 type Parser struct{}
 
@@ -8,13 +18,62 @@ func NewParser() *Parser {
 	return &Parser{}
 }
 
-func (p *Parser) Parse(base, update string) (map[int]Address, error) {
+func (p *Parser) Parse(base, update string) (*Directory, error) {
 	masterParser := parser.NewMasterParser()
 
-	addresses, err := masterParser.Parse(base, update)
+	dataset, err := masterParser.ParseDataset(base, update)
 	if err != nil {
 		return nil, err
 	}
 
-	return addresses, nil
+	return directory.New(dataset), nil
+}
+
+func (p *Parser) ParseDataset(base, update string) (*Dataset, error) {
+	masterParser := parser.NewMasterParser()
+
+	return masterParser.ParseDataset(base, update)
+}
+
+func (p *Parser) ParseDelta(update string) (*Delta, error) {
+	deltaParser := parser.NewDeltaParser()
+
+	return deltaParser.Parse(update)
+}
+
+// DetectFormat tells whether the release in dir uses the '@'-delimited or
+// the fixed-width layout. The parsers detect it on their own, file by file.
+func DetectFormat(dir string) (Format, error) {
+	return parser.DetectFormat(dir)
+}
+
+type (
+	Encoding  = parser.Encoding
+	Detection = parser.Detection
+)
+
+const (
+	EncodingWindows1252 = parser.EncodingWindows1252
+	EncodingUTF8        = parser.EncodingUTF8
+)
+
+// Detect reports the format, delimiter and encoding sniffed from each eDNE
+// file in dir.
+func Detect(dir string) ([]Detection, error) {
+	return parser.Detect(dir)
+}
+
+// ParseLargeUsers reads LOG_GRANDE_USUARIO from dir.
+func ParseLargeUsers(dir string) ([]LargeUser, error) {
+	return parser.ParseLargeUsers(dir)
+}
+
+// ParseOperationalUnits reads LOG_UNID_OPER from dir.
+func ParseOperationalUnits(dir string) ([]OperationalUnit, error) {
+	return parser.ParseOperationalUnits(dir)
+}
+
+// ParseCPCs reads LOG_CPC from dir.
+func ParseCPCs(dir string) ([]CPC, error) {
+	return parser.ParseCPCs(dir)
 }
@@ -0,0 +1,29 @@
+package edne
+
+import "github.com/NSXBet/edne/internal/postal"
+
+type (
+	Abbreviations = postal.Abbreviations
+	FormatOption  = postal.FormatOption
+	FormatOptions = postal.FormatOptions
+)
+
+var (
+	WithNumber            = postal.WithNumber
+	WithAddressComplement = postal.WithComplement
+	WithAbbreviations     = postal.WithAbbreviations
+	WithAbbreviate        = postal.WithAbbreviate
+	WithMaxLineLength     = postal.WithMaxLineLength
+)
+
+// AddressLines returns an address as written on envelopes: street, number
+// and complement; neighborhood; city and UF; and the masked CEP. Use
+// Directory.PostalLines to abbreviate with the names eDNE gives.
+func AddressLines(address Address, opts ...FormatOption) []string {
+	return postal.Lines(address, opts...)
+}
+
+// FormatAddress returns AddressLines joined by newlines.
+func FormatAddress(address Address, opts ...FormatOption) string {
+	return postal.Format(address, opts...)
+}
@@ -0,0 +1,33 @@
+package edne
+
+import "github.com/NSXBet/edne/internal/reload"
+
+type (
+	Holder        = reload.Holder
+	HolderOption  = reload.HolderOption
+	HolderOptions = reload.HolderOptions
+	ReloadEvent   = reload.Event
+	Loader        = reload.Loader
+)
+
+var (
+	WithOnReload     = reload.WithOnReload
+	WithPollInterval = reload.WithPollInterval
+)
+
+// NewHolder serves the dataset returned by loader, swapping in a new one on
+// every Reload. Call Reload once before serving.
+func NewHolder(loader Loader, opts ...HolderOption) *Holder {
+	return reload.New(loader, opts...)
+}
+
+// LoadDirectories is a Loader for an eDNE base and its delta.
+func LoadDirectories(base, update string) Loader {
+	return reload.FromDirectories(base, update)
+}
+
+// LoadArchives is a Loader for an eDNE base and its delta, each a directory
+// or a Correios zip. The dataset Release is named after the paths given.
+func LoadArchives(base, update string) Loader {
+	return reload.FromArchives(base, update)
+}
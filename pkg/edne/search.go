@@ -0,0 +1,23 @@
+package edne
+
+import "github.com/NSXBet/edne/internal/search"
+
+type (
+	SearchOption  = search.SearchOption
+	SearchOptions = search.SearchOptions
+	SearchResult  = search.Result
+	AddressMatch  = search.Match
+)
+
+var (
+	WithState        = search.WithState
+	WithCity         = search.WithCity
+	WithNeighborhood = search.WithNeighborhood
+	WithLimit        = search.WithLimit
+)
+
+// Normalize folds a name to lower case without diacritics, the form used to
+// compare names in searches.
+func Normalize(s string) string {
+	return search.Normalize(s)
+}
@@ -0,0 +1,22 @@
+package edne
+
+import "github.com/NSXBet/edne/internal/snapshot"
+
+const SnapshotFormatVersion = snapshot.FormatVersion
+
+var (
+	ErrInvalidSnapshot     = snapshot.ErrInvalidSnapshot
+	ErrIncompatibleVersion = snapshot.ErrIncompatibleVersion
+	ErrChecksumMismatch    = snapshot.ErrChecksumMismatch
+)
+
+// SaveSnapshot writes the parsed dataset to a versioned, checksummed binary
+// file that LoadSnapshot reads back without re-parsing the eDNE text files.
+func SaveSnapshot(filepath string, dataset *Dataset) error {
+	return snapshot.Save(filepath, dataset)
+}
+
+// LoadSnapshot reads a dataset written by SaveSnapshot.
+func LoadSnapshot(filepath string) (*Dataset, error) {
+	return snapshot.Load(filepath)
+}
@@ -0,0 +1,46 @@
+package edne
+
+import (
+	"io"
+
+	"github.com/NSXBet/edne/internal/export"
+	"github.com/NSXBet/edne/internal/stats"
+)
+
+type (
+	Stats         = stats.Stats
+	StatsOption   = stats.StatsOption
+	StatsOptions  = stats.StatsOptions
+	StatsCounts   = stats.Counts
+	StatsRow      = stats.Row
+	StatsGrouping = stats.Grouping
+)
+
+const (
+	GroupState        = stats.GroupState
+	GroupLocality     = stats.GroupLocality
+	GroupLocalityType = stats.GroupLocalityType
+)
+
+var (
+	WithLargeUsers       = stats.WithLargeUsers
+	WithOperationalUnits = stats.WithOperationalUnits
+	WithCPCs             = stats.WithCPCs
+)
+
+// NewStats counts the localities, neighborhoods and CEPs of dataset by UF,
+// locality and locality type.
+func NewStats(dataset *Dataset, opts ...StatsOption) *Stats {
+	return stats.New(dataset, opts...)
+}
+
+// StatsChanges returns how the counts of each group moved between two
+// releases, leaving out the groups that did not change.
+func StatsChanges(previous, current []StatsRow) []StatsRow {
+	return stats.Changes(previous, current)
+}
+
+// ExportStats writes statistics rows as JSON Lines or CSV.
+func ExportStats(w io.Writer, format ExportFormat, rows []StatsRow, opts ...ExportOption) error {
+	return export.Stats(w, format, rows, opts...)
+}
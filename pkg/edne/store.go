@@ -0,0 +1,19 @@
+package edne
+
+import "github.com/NSXBet/edne/internal/store"
+
+// AddressStore is a read-only, memory-mapped address store. Every process
+// opening the same file shares its pages.
+type AddressStore = store.Store
+
+var ErrInvalidStore = store.ErrInvalidStore
+
+// BuildAddressStore writes addresses to a store file for OpenAddressStore.
+func BuildAddressStore(filepath string, addresses map[int]Address) error {
+	return store.Build(filepath, addresses)
+}
+
+// OpenAddressStore maps a store file written by BuildAddressStore.
+func OpenAddressStore(filepath string) (*AddressStore, error) {
+	return store.Open(filepath)
+}
@@ -3,12 +3,39 @@ package edne
 import "github.com/NSXBet/edne/internal/models"
 
 type (
-	Address           = models.Address
-	LocationSituation = models.LocationSituation
-	LocationType      = models.LocationType
-	Location          = models.Location
-	Street            = models.Street
-	Neighborhood      = models.Neighborhood
+	Address            = models.Address
+	LocationSituation  = models.LocationSituation
+	LocationType       = models.LocationType
+	Location           = models.Location
+	Street             = models.Street
+	Neighborhood       = models.Neighborhood
+	Dataset            = models.Dataset
+	Delta              = models.Delta
+	Operation          = models.Operation
+	LocationChange     = models.LocationChange
+	NeighborhoodChange = models.NeighborhoodChange
+	StreetChange       = models.StreetChange
+	NumberRange        = models.NumberRange
+	NumberRangeChange  = models.NumberRangeChange
+	NumberSide         = models.NumberSide
+
+	LargeUser             = models.LargeUser
+	OperationalUnit       = models.OperationalUnit
+	CPC                   = models.CPC
+	Country               = models.Country
+	StateRange            = models.StateRange
+	LocationRangeType     = models.LocationRangeType
+	LocationRange         = models.LocationRange
+	NeighborhoodRange     = models.NeighborhoodRange
+	CPCRange              = models.CPCRange
+	OperationalUnitRange  = models.OperationalUnitRange
+	LocationVariation     = models.LocationVariation
+	NeighborhoodVariation = models.NeighborhoodVariation
+	StreetVariation       = models.StreetVariation
+
+	Release       = models.Release
+	ReleaseSource = models.Source
+	ReleaseFile   = models.File
 )
 
 const (
@@ -22,3 +49,22 @@ const (
 	LocationTypeCity     = models.LocationTypeCity
 	LocationTypeVillage  = models.LocationTypeVillage
 )
+
+const (
+	LocationRangeTypeTotal = models.LocationRangeTypeTotal
+	LocationRangeTypeUrban = models.LocationRangeTypeUrban
+)
+
+const (
+	OperationInsert = models.OperationInsert
+	OperationUpdate = models.OperationUpdate
+	OperationDelete = models.OperationDelete
+)
+
+const (
+	NumberSideBoth  = models.NumberSideBoth
+	NumberSideEven  = models.NumberSideEven
+	NumberSideOdd   = models.NumberSideOdd
+	NumberSideRight = models.NumberSideRight
+	NumberSideLeft  = models.NumberSideLeft
+)
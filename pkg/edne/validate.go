@@ -0,0 +1,10 @@
+package edne
+
+import "github.com/NSXBet/edne/internal/validate"
+
+type ValidationIssue = validate.Issue
+
+// Validate checks the references between the entities of a dataset.
+func Validate(dataset *Dataset) []ValidationIssue {
+	return validate.Dataset(dataset)
+}
@@ -0,0 +1,10 @@
+package edne
+
+import "github.com/NSXBet/edne/internal/writer"
+
+// WriteDataset writes a dataset to dir as the LOG_ files of a base release,
+// which Parser.ParseDataset reads back. Use it to hand trimmed or synthetic releases
+// to partners and tests.
+func WriteDataset(dir string, dataset *Dataset) error {
+	return writer.Dataset(dir, dataset)
+}
@@ -0,0 +1,20 @@
+package edne
+
+import "github.com/NSXBet/edne/internal/models"
+
+var ErrInvalidZipCode = models.ErrInvalidZipCode
+
+// ParseZipCode reads a CEP written as "29127225" or "29127-225".
+func ParseZipCode(s string) (int, error) {
+	return models.ParseZipCode(s)
+}
+
+// FormatZipCode writes a CEP with its eight digits, as in "06415235".
+func FormatZipCode(zipCode int) string {
+	return models.FormatZipCode(zipCode)
+}
+
+// MaskZipCode writes a CEP in the "06415-235" form.
+func MaskZipCode(zipCode int) string {
+	return models.MaskZipCode(zipCode)
+}
@@ -0,0 +1,1072 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: edne/v1/edne.proto
+
+package ednepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LocationSituation int32
+
+const (
+	LocationSituation_LOCATION_SITUATION_NON_CODIFIED      LocationSituation = 0
+	LocationSituation_LOCATION_SITUATION_CODIFIED_STREET   LocationSituation = 1
+	LocationSituation_LOCATION_SITUATION_CODIFIED_DISTRICT LocationSituation = 2
+)
+
+// Enum value maps for LocationSituation.
+var (
+	LocationSituation_name = map[int32]string{
+		0: "LOCATION_SITUATION_NON_CODIFIED",
+		1: "LOCATION_SITUATION_CODIFIED_STREET",
+		2: "LOCATION_SITUATION_CODIFIED_DISTRICT",
+	}
+	LocationSituation_value = map[string]int32{
+		"LOCATION_SITUATION_NON_CODIFIED":      0,
+		"LOCATION_SITUATION_CODIFIED_STREET":   1,
+		"LOCATION_SITUATION_CODIFIED_DISTRICT": 2,
+	}
+)
+
+func (x LocationSituation) Enum() *LocationSituation {
+	p := new(LocationSituation)
+	*p = x
+	return p
+}
+
+func (x LocationSituation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LocationSituation) Descriptor() protoreflect.EnumDescriptor {
+	return file_edne_v1_edne_proto_enumTypes[0].Descriptor()
+}
+
+func (LocationSituation) Type() protoreflect.EnumType {
+	return &file_edne_v1_edne_proto_enumTypes[0]
+}
+
+func (x LocationSituation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LocationSituation.Descriptor instead.
+func (LocationSituation) EnumDescriptor() ([]byte, []int) {
+	return file_edne_v1_edne_proto_rawDescGZIP(), []int{0}
+}
+
+type LocationType int32
+
+const (
+	LocationType_LOCATION_TYPE_UNSPECIFIED LocationType = 0
+	LocationType_LOCATION_TYPE_DISTRICT    LocationType = 1
+	LocationType_LOCATION_TYPE_CITY        LocationType = 2
+	LocationType_LOCATION_TYPE_VILLAGE     LocationType = 3
+)
+
+// Enum value maps for LocationType.
+var (
+	LocationType_name = map[int32]string{
+		0: "LOCATION_TYPE_UNSPECIFIED",
+		1: "LOCATION_TYPE_DISTRICT",
+		2: "LOCATION_TYPE_CITY",
+		3: "LOCATION_TYPE_VILLAGE",
+	}
+	LocationType_value = map[string]int32{
+		"LOCATION_TYPE_UNSPECIFIED": 0,
+		"LOCATION_TYPE_DISTRICT":    1,
+		"LOCATION_TYPE_CITY":        2,
+		"LOCATION_TYPE_VILLAGE":     3,
+	}
+)
+
+func (x LocationType) Enum() *LocationType {
+	p := new(LocationType)
+	*p = x
+	return p
+}
+
+func (x LocationType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LocationType) Descriptor() protoreflect.EnumDescriptor {
+	return file_edne_v1_edne_proto_enumTypes[1].Descriptor()
+}
+
+func (LocationType) Type() protoreflect.EnumType {
+	return &file_edne_v1_edne_proto_enumTypes[1]
+}
+
+func (x LocationType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LocationType.Descriptor instead.
+func (LocationType) EnumDescriptor() ([]byte, []int) {
+	return file_edne_v1_edne_proto_rawDescGZIP(), []int{1}
+}
+
+// Address is a CEP joined with its street, neighborhood and locality.
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cep           string                 `protobuf:"bytes,1,opt,name=cep,proto3" json:"cep,omitempty"`
+	StreetType    string                 `protobuf:"bytes,2,opt,name=street_type,json=streetType,proto3" json:"street_type,omitempty"`
+	Street        string                 `protobuf:"bytes,3,opt,name=street,proto3" json:"street,omitempty"`
+	Neighborhood  string                 `protobuf:"bytes,4,opt,name=neighborhood,proto3" json:"neighborhood,omitempty"`
+	City          string                 `protobuf:"bytes,5,opt,name=city,proto3" json:"city,omitempty"`
+	State         string                 `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
+	CityIbgeCode  string                 `protobuf:"bytes,7,opt,name=city_ibge_code,json=cityIbgeCode,proto3" json:"city_ibge_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_edne_v1_edne_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_edne_v1_edne_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_edne_v1_edne_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Address) GetCep() string {
+	if x != nil {
+		return x.Cep
+	}
+	return ""
+}
+
+func (x *Address) GetStreetType() string {
+	if x != nil {
+		return x.StreetType
+	}
+	return ""
+}
+
+func (x *Address) GetStreet() string {
+	if x != nil {
+		return x.Street
+	}
+	return ""
+}
+
+func (x *Address) GetNeighborhood() string {
+	if x != nil {
+		return x.Neighborhood
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Address) GetCityIbgeCode() string {
+	if x != nil {
+		return x.CityIbgeCode
+	}
+	return ""
+}
+
+// Location is a locality from LOG_LOCALIDADE.
+type Location struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	State                 string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Name                  string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Cep                   string                 `protobuf:"bytes,4,opt,name=cep,proto3" json:"cep,omitempty"`
+	Situation             LocationSituation      `protobuf:"varint,5,opt,name=situation,proto3,enum=edne.v1.LocationSituation" json:"situation,omitempty"`
+	Type                  LocationType           `protobuf:"varint,6,opt,name=type,proto3,enum=edne.v1.LocationType" json:"type,omitempty"`
+	SubordinateLocationId int64                  `protobuf:"varint,7,opt,name=subordinate_location_id,json=subordinateLocationId,proto3" json:"subordinate_location_id,omitempty"`
+	Abbreviation          string                 `protobuf:"bytes,8,opt,name=abbreviation,proto3" json:"abbreviation,omitempty"`
+	IbgeCode              string                 `protobuf:"bytes,9,opt,name=ibge_code,json=ibgeCode,proto3" json:"ibge_code,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_edne_v1_edne_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_edne_v1_edne_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_edne_v1_edne_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Location) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Location) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Location) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Location) GetCep() string {
+	if x != nil {
+		return x.Cep
+	}
+	return ""
+}
+
+func (x *Location) GetSituation() LocationSituation {
+	if x != nil {
+		return x.Situation
+	}
+	return LocationSituation_LOCATION_SITUATION_NON_CODIFIED
+}
+
+func (x *Location) GetType() LocationType {
+	if x != nil {
+		return x.Type
+	}
+	return LocationType_LOCATION_TYPE_UNSPECIFIED
+}
+
+func (x *Location) GetSubordinateLocationId() int64 {
+	if x != nil {
+		return x.SubordinateLocationId
+	}
+	return 0
+}
+
+func (x *Location) GetAbbreviation() string {
+	if x != nil {
+		return x.Abbreviation
+	}
+	return ""
+}
+
+func (x *Location) GetIbgeCode() string {
+	if x != nil {
+		return x.IbgeCode
+	}
+	return ""
+}
+
+// Neighborhood is a neighborhood from LOG_BAIRRO.
+type Neighborhood struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	LocationId    int64                  `protobuf:"varint,3,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Abbreviation  string                 `protobuf:"bytes,5,opt,name=abbreviation,proto3" json:"abbreviation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Neighborhood) Reset() {
+	*x = Neighborhood{}
+	mi := &file_edne_v1_edne_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Neighborhood) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Neighborhood) ProtoMessage() {}
+
+func (x *Neighborhood) ProtoReflect() protoreflect.Message {
+	mi := &file_edne_v1_edne_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Neighborhood.ProtoReflect.Descriptor instead.
+func (*Neighborhood) Descriptor() ([]byte, []int) {
+	return file_edne_v1_edne_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Neighborhood) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Neighborhood) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Neighborhood) GetLocationId() int64 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *Neighborhood) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Neighborhood) GetAbbreviation() string {
+	if x != nil {
+		return x.Abbreviation
+	}
+	return ""
+}
+
+// Street is a street from LOG_LOGRADOURO_XX.
+type Street struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Id                     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Cep                    string                 `protobuf:"bytes,2,opt,name=cep,proto3" json:"cep,omitempty"`
+	State                  string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	LocationId             int64                  `protobuf:"varint,4,opt,name=location_id,json=locationId,proto3" json:"location_id,omitempty"`
+	StartingNeighborhoodId int64                  `protobuf:"varint,5,opt,name=starting_neighborhood_id,json=startingNeighborhoodId,proto3" json:"starting_neighborhood_id,omitempty"`
+	EndingNeighborhoodId   int64                  `protobuf:"varint,6,opt,name=ending_neighborhood_id,json=endingNeighborhoodId,proto3" json:"ending_neighborhood_id,omitempty"`
+	Name                   string                 `protobuf:"bytes,7,opt,name=name,proto3" json:"name,omitempty"`
+	Complement             string                 `protobuf:"bytes,8,opt,name=complement,proto3" json:"complement,omitempty"`
+	Type                   string                 `protobuf:"bytes,9,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Street) Reset() {
+	*x = Street{}
+	mi := &file_edne_v1_edne_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Street) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Street) ProtoMessage() {}
+
+func (x *Street) ProtoReflect() protoreflect.Message {
+	mi := &file_edne_v1_edne_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Street.ProtoReflect.Descriptor instead.
+func (*Street) Descriptor() ([]byte, []int) {
+	return file_edne_v1_edne_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Street) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Street) GetCep() string {
+	if x != nil {
+		return x.Cep
+	}
+	return ""
+}
+
+func (x *Street) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Street) GetLocationId() int64 {
+	if x != nil {
+		return x.LocationId
+	}
+	return 0
+}
+
+func (x *Street) GetStartingNeighborhoodId() int64 {
+	if x != nil {
+		return x.StartingNeighborhoodId
+	}
+	return 0
+}
+
+func (x *Street) GetEndingNeighborhoodId() int64 {
+	if x != nil {
+		return x.EndingNeighborhoodId
+	}
+	return 0
+}
+
+func (x *Street) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Street) GetComplement() string {
+	if x != nil {
+		return x.Complement
+	}
+	return ""
+}
+
+func (x *Street) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type LookupRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// cep is written as "29127225" or "29127-225".
+	Cep           string `protobuf:"bytes,1,opt,name=cep,proto3" json:"cep,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupRequest) Reset() {
+	*x = LookupRequest{}
+	mi := &file_edne_v1_edne_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupRequest) ProtoMessage() {}
+
+func (x *LookupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_edne_v1_edne_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupRequest.ProtoReflect.Descriptor instead.
+func (*LookupRequest) Descriptor() ([]byte, []int) {
+	return file_edne_v1_edne_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *LookupRequest) GetCep() string {
+	if x != nil {
+		return x.Cep
+	}
+	return ""
+}
+
+type LookupResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// street, location and neighborhood are unset when the CEP has no such
+	// record in the dataset.
+	Street        *Street       `protobuf:"bytes,2,opt,name=street,proto3" json:"street,omitempty"`
+	Location      *Location     `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Neighborhood  *Neighborhood `protobuf:"bytes,4,opt,name=neighborhood,proto3" json:"neighborhood,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LookupResponse) Reset() {
+	*x = LookupResponse{}
+	mi := &file_edne_v1_edne_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LookupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LookupResponse) ProtoMessage() {}
+
+func (x *LookupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_edne_v1_edne_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LookupResponse.ProtoReflect.Descriptor instead.
+func (*LookupResponse) Descriptor() ([]byte, []int) {
+	return file_edne_v1_edne_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LookupResponse) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *LookupResponse) GetStreet() *Street {
+	if x != nil {
+		return x.Street
+	}
+	return nil
+}
+
+func (x *LookupResponse) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *LookupResponse) GetNeighborhood() *Neighborhood {
+	if x != nil {
+		return x.Neighborhood
+	}
+	return nil
+}
+
+type BatchLookupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ceps          []string               `protobuf:"bytes,1,rep,name=ceps,proto3" json:"ceps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchLookupRequest) Reset() {
+	*x = BatchLookupRequest{}
+	mi := &file_edne_v1_edne_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchLookupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchLookupRequest) ProtoMessage() {}
+
+func (x *BatchLookupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_edne_v1_edne_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchLookupRequest.ProtoReflect.Descriptor instead.
+func (*BatchLookupRequest) Descriptor() ([]byte, []int) {
+	return file_edne_v1_edne_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BatchLookupRequest) GetCeps() []string {
+	if x != nil {
+		return x.Ceps
+	}
+	return nil
+}
+
+type BatchLookupResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Cep     string                 `protobuf:"bytes,1,opt,name=cep,proto3" json:"cep,omitempty"`
+	Found   bool                   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	Address *Address               `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	// error explains why a malformed CEP was not looked up.
+	Error         string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchLookupResult) Reset() {
+	*x = BatchLookupResult{}
+	mi := &file_edne_v1_edne_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchLookupResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchLookupResult) ProtoMessage() {}
+
+func (x *BatchLookupResult) ProtoReflect() protoreflect.Message {
+	mi := &file_edne_v1_edne_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchLookupResult.ProtoReflect.Descriptor instead.
+func (*BatchLookupResult) Descriptor() ([]byte, []int) {
+	return file_edne_v1_edne_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BatchLookupResult) GetCep() string {
+	if x != nil {
+		return x.Cep
+	}
+	return ""
+}
+
+func (x *BatchLookupResult) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *BatchLookupResult) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *BatchLookupResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BatchLookupResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// results follow the order of the request.
+	Results       []*BatchLookupResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchLookupResponse) Reset() {
+	*x = BatchLookupResponse{}
+	mi := &file_edne_v1_edne_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchLookupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchLookupResponse) ProtoMessage() {}
+
+func (x *BatchLookupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_edne_v1_edne_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchLookupResponse.ProtoReflect.Descriptor instead.
+func (*BatchLookupResponse) Descriptor() ([]byte, []int) {
+	return file_edne_v1_edne_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BatchLookupResponse) GetResults() []*BatchLookupResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	City          string                 `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	Neighborhood  string                 `protobuf:"bytes,4,opt,name=neighborhood,proto3" json:"neighborhood,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_edne_v1_edne_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_edne_v1_edne_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_edne_v1_edne_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetNeighborhood() string {
+	if x != nil {
+		return x.Neighborhood
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_edne_v1_edne_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_edne_v1_edne_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_edne_v1_edne_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchResult) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *SearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+var File_edne_v1_edne_proto protoreflect.FileDescriptor
+
+const file_edne_v1_edne_proto_rawDesc = "" +
+	"\n" +
+	"\x12edne/v1/edne.proto\x12\aedne.v1\"\xc8\x01\n" +
+	"\aAddress\x12\x10\n" +
+	"\x03cep\x18\x01 \x01(\tR\x03cep\x12\x1f\n" +
+	"\vstreet_type\x18\x02 \x01(\tR\n" +
+	"streetType\x12\x16\n" +
+	"\x06street\x18\x03 \x01(\tR\x06street\x12\"\n" +
+	"\fneighborhood\x18\x04 \x01(\tR\fneighborhood\x12\x12\n" +
+	"\x04city\x18\x05 \x01(\tR\x04city\x12\x14\n" +
+	"\x05state\x18\x06 \x01(\tR\x05state\x12$\n" +
+	"\x0ecity_ibge_code\x18\a \x01(\tR\fcityIbgeCode\"\xb4\x02\n" +
+	"\bLocation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x10\n" +
+	"\x03cep\x18\x04 \x01(\tR\x03cep\x128\n" +
+	"\tsituation\x18\x05 \x01(\x0e2\x1a.edne.v1.LocationSituationR\tsituation\x12)\n" +
+	"\x04type\x18\x06 \x01(\x0e2\x15.edne.v1.LocationTypeR\x04type\x126\n" +
+	"\x17subordinate_location_id\x18\a \x01(\x03R\x15subordinateLocationId\x12\"\n" +
+	"\fabbreviation\x18\b \x01(\tR\fabbreviation\x12\x1b\n" +
+	"\tibge_code\x18\t \x01(\tR\bibgeCode\"\x8d\x01\n" +
+	"\fNeighborhood\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x1f\n" +
+	"\vlocation_id\x18\x03 \x01(\x03R\n" +
+	"locationId\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\"\n" +
+	"\fabbreviation\x18\x05 \x01(\tR\fabbreviation\"\x99\x02\n" +
+	"\x06Street\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03cep\x18\x02 \x01(\tR\x03cep\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12\x1f\n" +
+	"\vlocation_id\x18\x04 \x01(\x03R\n" +
+	"locationId\x128\n" +
+	"\x18starting_neighborhood_id\x18\x05 \x01(\x03R\x16startingNeighborhoodId\x124\n" +
+	"\x16ending_neighborhood_id\x18\x06 \x01(\x03R\x14endingNeighborhoodId\x12\x12\n" +
+	"\x04name\x18\a \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"complement\x18\b \x01(\tR\n" +
+	"complement\x12\x12\n" +
+	"\x04type\x18\t \x01(\tR\x04type\"!\n" +
+	"\rLookupRequest\x12\x10\n" +
+	"\x03cep\x18\x01 \x01(\tR\x03cep\"\xcf\x01\n" +
+	"\x0eLookupResponse\x12*\n" +
+	"\aaddress\x18\x01 \x01(\v2\x10.edne.v1.AddressR\aaddress\x12'\n" +
+	"\x06street\x18\x02 \x01(\v2\x0f.edne.v1.StreetR\x06street\x12-\n" +
+	"\blocation\x18\x03 \x01(\v2\x11.edne.v1.LocationR\blocation\x129\n" +
+	"\fneighborhood\x18\x04 \x01(\v2\x15.edne.v1.NeighborhoodR\fneighborhood\"(\n" +
+	"\x12BatchLookupRequest\x12\x12\n" +
+	"\x04ceps\x18\x01 \x03(\tR\x04ceps\"}\n" +
+	"\x11BatchLookupResult\x12\x10\n" +
+	"\x03cep\x18\x01 \x01(\tR\x03cep\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\x12*\n" +
+	"\aaddress\x18\x03 \x01(\v2\x10.edne.v1.AddressR\aaddress\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"K\n" +
+	"\x13BatchLookupResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.edne.v1.BatchLookupResultR\aresults\"\x89\x01\n" +
+	"\rSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12\x12\n" +
+	"\x04city\x18\x03 \x01(\tR\x04city\x12\"\n" +
+	"\fneighborhood\x18\x04 \x01(\tR\fneighborhood\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"P\n" +
+	"\fSearchResult\x12*\n" +
+	"\aaddress\x18\x01 \x01(\v2\x10.edne.v1.AddressR\aaddress\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score*\x8a\x01\n" +
+	"\x11LocationSituation\x12#\n" +
+	"\x1fLOCATION_SITUATION_NON_CODIFIED\x10\x00\x12&\n" +
+	"\"LOCATION_SITUATION_CODIFIED_STREET\x10\x01\x12(\n" +
+	"$LOCATION_SITUATION_CODIFIED_DISTRICT\x10\x02*|\n" +
+	"\fLocationType\x12\x1d\n" +
+	"\x19LOCATION_TYPE_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16LOCATION_TYPE_DISTRICT\x10\x01\x12\x16\n" +
+	"\x12LOCATION_TYPE_CITY\x10\x02\x12\x19\n" +
+	"\x15LOCATION_TYPE_VILLAGE\x10\x032\xcf\x01\n" +
+	"\rLookupService\x129\n" +
+	"\x06Lookup\x12\x16.edne.v1.LookupRequest\x1a\x17.edne.v1.LookupResponse\x12H\n" +
+	"\vBatchLookup\x12\x1b.edne.v1.BatchLookupRequest\x1a\x1c.edne.v1.BatchLookupResponse\x129\n" +
+	"\x06Search\x12\x16.edne.v1.SearchRequest\x1a\x15.edne.v1.SearchResult0\x01B*Z(github.com/NSXBet/edne/pkg/ednepb;ednepbb\x06proto3"
+
+var (
+	file_edne_v1_edne_proto_rawDescOnce sync.Once
+	file_edne_v1_edne_proto_rawDescData []byte
+)
+
+func file_edne_v1_edne_proto_rawDescGZIP() []byte {
+	file_edne_v1_edne_proto_rawDescOnce.Do(func() {
+		file_edne_v1_edne_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_edne_v1_edne_proto_rawDesc), len(file_edne_v1_edne_proto_rawDesc)))
+	})
+	return file_edne_v1_edne_proto_rawDescData
+}
+
+var file_edne_v1_edne_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_edne_v1_edne_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_edne_v1_edne_proto_goTypes = []any{
+	(LocationSituation)(0),      // 0: edne.v1.LocationSituation
+	(LocationType)(0),           // 1: edne.v1.LocationType
+	(*Address)(nil),             // 2: edne.v1.Address
+	(*Location)(nil),            // 3: edne.v1.Location
+	(*Neighborhood)(nil),        // 4: edne.v1.Neighborhood
+	(*Street)(nil),              // 5: edne.v1.Street
+	(*LookupRequest)(nil),       // 6: edne.v1.LookupRequest
+	(*LookupResponse)(nil),      // 7: edne.v1.LookupResponse
+	(*BatchLookupRequest)(nil),  // 8: edne.v1.BatchLookupRequest
+	(*BatchLookupResult)(nil),   // 9: edne.v1.BatchLookupResult
+	(*BatchLookupResponse)(nil), // 10: edne.v1.BatchLookupResponse
+	(*SearchRequest)(nil),       // 11: edne.v1.SearchRequest
+	(*SearchResult)(nil),        // 12: edne.v1.SearchResult
+}
+var file_edne_v1_edne_proto_depIdxs = []int32{
+	0,  // 0: edne.v1.Location.situation:type_name -> edne.v1.LocationSituation
+	1,  // 1: edne.v1.Location.type:type_name -> edne.v1.LocationType
+	2,  // 2: edne.v1.LookupResponse.address:type_name -> edne.v1.Address
+	5,  // 3: edne.v1.LookupResponse.street:type_name -> edne.v1.Street
+	3,  // 4: edne.v1.LookupResponse.location:type_name -> edne.v1.Location
+	4,  // 5: edne.v1.LookupResponse.neighborhood:type_name -> edne.v1.Neighborhood
+	2,  // 6: edne.v1.BatchLookupResult.address:type_name -> edne.v1.Address
+	9,  // 7: edne.v1.BatchLookupResponse.results:type_name -> edne.v1.BatchLookupResult
+	2,  // 8: edne.v1.SearchResult.address:type_name -> edne.v1.Address
+	6,  // 9: edne.v1.LookupService.Lookup:input_type -> edne.v1.LookupRequest
+	8,  // 10: edne.v1.LookupService.BatchLookup:input_type -> edne.v1.BatchLookupRequest
+	11, // 11: edne.v1.LookupService.Search:input_type -> edne.v1.SearchRequest
+	7,  // 12: edne.v1.LookupService.Lookup:output_type -> edne.v1.LookupResponse
+	10, // 13: edne.v1.LookupService.BatchLookup:output_type -> edne.v1.BatchLookupResponse
+	12, // 14: edne.v1.LookupService.Search:output_type -> edne.v1.SearchResult
+	12, // [12:15] is the sub-list for method output_type
+	9,  // [9:12] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_edne_v1_edne_proto_init() }
+func file_edne_v1_edne_proto_init() {
+	if File_edne_v1_edne_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_edne_v1_edne_proto_rawDesc), len(file_edne_v1_edne_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_edne_v1_edne_proto_goTypes,
+		DependencyIndexes: file_edne_v1_edne_proto_depIdxs,
+		EnumInfos:         file_edne_v1_edne_proto_enumTypes,
+		MessageInfos:      file_edne_v1_edne_proto_msgTypes,
+	}.Build()
+	File_edne_v1_edne_proto = out.File
+	file_edne_v1_edne_proto_goTypes = nil
+	file_edne_v1_edne_proto_depIdxs = nil
+}